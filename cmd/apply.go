@@ -0,0 +1,165 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+const (
+	applyDesc = `
+	Create or update a deployment from a declarative deployment spec.
+
+	The deployment spec is a YAML file that defines a deployment name, the helm chart version, helm values,
+	an Enterprise license, environments and models. The command compares the spec with the current state
+	of the deployment and applies only the required changes, running the command again for the same spec
+	doesn't change anything.
+
+	Helm values of the current deployment are not reused, a value removed from the spec is removed from
+	the deployment. Values set by rasactl when the deployment was created, e.g. the mounted project,
+	are kept.
+
+	Use the 'rasactl diff' command to see changes without applying them.
+
+	If the spec defines a project path and the deployment runs in a remote cluster, the project is synced
+	once when the deployment is created, use the 'rasactl sync' command to keep syncing changes.
+`
+
+	applyExample = `
+	# Create or update a deployment defined in the deployment.yaml file.
+	$ rasactl apply -f deployment.yaml
+
+	# Provide the Rasa X password using STDIN, the password is used if a new deployment is created.
+	$ rasactl apply -f deployment.yaml --rasa-x-password-stdin
+`
+)
+
+func applyCmd() *cobra.Command {
+
+	// cmd represents the apply command
+	cmd := &cobra.Command{
+		Use:     "apply -f FILE",
+		Short:   "create or update a deployment from a deployment spec",
+		Long:    templates.LongDesc(applyDesc),
+		Example: templates.Examples(applyExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.CheckHelmChartDir()
+
+			if rasactlFlags.Start.RasaXPasswordStdin {
+				password, err := utils.GetPasswordStdin()
+				if err != nil {
+					return err
+				}
+				rasactlFlags.Start.RasaXPassword = password
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := setDeploymentSpec(rasactlFlags.Apply.File)
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.Apply(spec); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	applyFlags(cmd)
+	//nolint:golint,errcheck
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// setDeploymentSpec reads a deployment spec and configures clients for the deployment defined by the spec.
+func setDeploymentSpec(file string) (*types.DeploymentSpec, error) {
+	spec, err := utils.ReadDeploymentSpec(file)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace = spec.Name
+	rasaCtl.Namespace = namespace
+	log.Info("Setting namespace", "namespace", namespace)
+	if err := rasaCtl.SetNamespaceClients(namespace); err != nil {
+		return nil, err
+	}
+
+	isNamespaceExist, err := rasaCtl.KubernetesClient.IsNamespaceExist(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNamespaceExist && !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+		return nil, xerrors.Errorf("The %s namespace exists but is not managed by rasactl, can't continue :(", namespace)
+	}
+
+	helmConfiguration.ReleaseName = spec.Chart.ReleaseName
+	if helmConfiguration.ReleaseName == "" {
+		helmConfiguration.ReleaseName = types.HelmChartNameRasaX
+	}
+	helmConfiguration.Version = spec.Chart.Version
+	if isNamespaceExist && rasaCtl.KubernetesClient.IsSecretWithStateExist() {
+		stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+		if err != nil {
+			return nil, err
+		}
+
+		releaseName := string(stateData[types.StateHelmReleaseName])
+		if spec.Chart.ReleaseName != "" && releaseName != spec.Chart.ReleaseName {
+			fmt.Printf("The %s deployment uses the %s helm release, the release name defined in the spec is ignored.\n",
+				namespace, releaseName)
+		}
+		helmConfiguration.ReleaseName = releaseName
+
+		if helmConfiguration.Version == "" {
+			helmConfiguration.Version = string(stateData[types.StateHelmChartVersion])
+		}
+	}
+
+	if helmConfiguration.Version == "" {
+		helmConfiguration.Version = types.HelmChartVersionRasaX
+	}
+
+	// Values of the current release are not reused, values removed from the spec are removed from the deployment.
+	// Values set by rasactl when the deployment was created are kept by the apply action.
+	helmConfiguration.ReuseValues = false
+
+	rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+	rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
+
+	return spec, nil
+}
+
+func init() {
+
+	applyCmd := applyCmd()
+	rootCmd.AddCommand(applyCmd)
+}
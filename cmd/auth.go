@@ -25,11 +25,12 @@ func authCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:       "auth",
 		Short:     "manage credentials for Rasa X / Enterprise",
-		ValidArgs: []string{"login", "logout"},
+		ValidArgs: []string{"login", "logout", "status"},
 	}
 
 	cmd.AddCommand(authLoginCmd())
 	cmd.AddCommand(authLogoutCmd())
+	cmd.AddCommand(authStatusCmd())
 
 	return cmd
 }
@@ -0,0 +1,99 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	authStatusDesc = `
+	Show if credentials for Rasa X / Enterprise are available, and where they come from.
+
+	Credentials are read from the RASACTL_AUTH_USER and RASACTL_AUTH_PASSWORD environment variables,
+	or from the credentials store if the 'rasactl auth login' command was used.
+`
+
+	authStatusExample = `
+	# Show authentication state (use the currently active deployment).
+	$ rasactl auth status
+
+	# Show authentication state for the 'my-deployment' deployment in the JSON format.
+	$ rasactl auth status my-deployment -o json
+`
+)
+
+func authStatusCmd() *cobra.Command {
+
+	// cmd represents the auth status command
+	cmd := &cobra.Command{
+		Use:     "status [DEPLOYMENT-NAME]",
+		Short:   "show authentication state",
+		Long:    templates.LongDesc(authStatusDesc),
+		Args:    cobra.MaximumNArgs(1),
+		Example: templates.Examples(authStatusExample),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := status.ValidateOutputFormat(rasactlFlags.Auth.Status.Output); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			if err := rasaCtl.AuthStatus(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	addOutputFlag(cmd, &rasactlFlags.Auth.Status.Output)
+
+	return cmd
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	backupDesc = `
+Create a backup of a deployment.
+
+The backup is a gzip-compressed tar archive that includes the PostgreSQL databases (Rasa X and tracker store),
+helm values, the rasactl state and models stored in Rasa X / Enterprise.
+
+Models are downloaded via the Rasa X API, it's required to be logged in, use the 'rasactl auth login' command
+or skip models with the --skip-models flag.
+`
+
+	backupExample = `
+	# Create a backup of the 'my-deployment' deployment.
+	$ rasactl backup my-deployment -o my-deployment.tar.gz
+
+	# Create a backup without models (use the currently active deployment).
+	$ rasactl backup --skip-models
+`
+)
+
+func backupCmd() *cobra.Command {
+
+	// cmd represents the backup command
+	cmd := &cobra.Command{
+		Use:     "backup [DEPLOYMENT-NAME]",
+		Short:   "create a backup of a deployment",
+		Long:    templates.LongDesc(backupDesc),
+		Example: templates.Examples(backupExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if rasactlFlags.Backup.File == "" {
+				rasactlFlags.Backup.File = fmt.Sprintf("%s-%s.tar.gz", rasaCtl.Namespace, time.Now().Format("20060102150405"))
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			// Check if a Rasa X deployment is running
+			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if !isRunning {
+				fmt.Printf("The %s deployment is not running.\n", rasaCtl.Namespace)
+				return nil
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.Backup(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	backupFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	backupCmd := backupCmd()
+	rootCmd.AddCommand(backupCmd)
+}
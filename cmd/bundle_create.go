@@ -0,0 +1,85 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+const (
+	bundleCreateDesc = `
+Create an air-gapped bundle.
+
+The bundle includes the rasa-x helm chart and all container images used by the chart,
+images are stored in the docker save format. The bundle can be used to create a deployment
+without access to a helm repository or a container registry, use the 'rasactl start --bundle' command.
+
+Images are resolved by rendering the helm chart, use the --values-file flag to pass the same configuration
+that is going to be used for a deployment.
+`
+
+	bundleCreateExample = `
+	# Create a bundle for the default version of the rasa-x helm chart.
+	$ rasactl bundle create
+
+	# Create a bundle for a given version of the rasa-x helm chart and custom configuration.
+	$ rasactl bundle create --rasa-x-chart-version 4.3.3 --values-file values.yaml -o rasa-x-bundle.tar.gz
+
+	# Create a deployment from the bundle.
+	$ rasactl start my-deployment --bundle rasa-x-bundle.tar.gz
+`
+)
+
+func bundleCreateCmd() *cobra.Command {
+
+	// cmd represents the bundle create command
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "create an air-gapped bundle with the helm chart and container images",
+		Long:    templates.LongDesc(bundleCreateDesc),
+		Example: templates.Examples(bundleCreateExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.CheckHelmChartDir()
+
+			if rasactlFlags.Bundle.Create.File == "" {
+				rasactlFlags.Bundle.Create.File = fmt.Sprintf("rasactl-bundle-%s.tar.gz", helmConfiguration.Version)
+			}
+
+			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.BundleCreate(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	bundleCreateFlags(cmd)
+
+	return cmd
+}
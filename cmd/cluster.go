@@ -0,0 +1,44 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func clusterCmd() *cobra.Command {
+
+	// cmd represents the cluster command
+	cmd := &cobra.Command{
+		Use:       "cluster",
+		Short:     "manage a local kind cluster",
+		ValidArgs: []string{"create", "delete", "status"},
+	}
+
+	cmd.AddCommand(clusterCreateCmd())
+	cmd.AddCommand(clusterDeleteCmd())
+	cmd.AddCommand(clusterStatusCmd())
+
+	clusterFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	clusterCmd := clusterCmd()
+	rootCmd.AddCommand(clusterCmd)
+}
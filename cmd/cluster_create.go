@@ -0,0 +1,73 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	clusterCreateDesc = `
+Create a local kind cluster for rasactl deployments.
+
+The command creates a kind control plane node with ports 80, 443, and 30000-30100 mapped to the host,
+installs the ingress-nginx controller, and configures CoreDNS, so that pods in the cluster resolve
+*.rasactl.localhost names to the ingress controller. The new cluster is set as the current
+Kubernetes context.
+
+If the cluster already exists, the command installs missing components only.
+`
+
+	clusterCreateExample = `
+	# Create a cluster and a deployment.
+	$ rasactl cluster create && rasactl start
+
+	# Create a cluster with a given kind node image.
+	$ rasactl cluster create --image kindest/node:v1.21.1
+`
+)
+
+func clusterCreateCmd() *cobra.Command {
+
+	// cmd represents the cluster create command
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "create a local kind cluster",
+		Long:    templates.LongDesc(clusterCreateDesc),
+		Example: templates.Examples(clusterCreateExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := rasaCtl.InitClusterClients(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.ClusterCreate(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	clusterCreateFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+)
+
+const (
+	clusterStatusDesc = `
+Show status of a local kind cluster: nodes, the ingress controller, and the CoreDNS configuration.
+`
+
+	clusterStatusExample = `
+	# Show the cluster status.
+	$ rasactl cluster status
+
+	# Show the cluster status in the JSON format.
+	$ rasactl cluster status -o json
+`
+)
+
+func clusterStatusCmd() *cobra.Command {
+
+	// cmd represents the cluster status command
+	cmd := &cobra.Command{
+		Use:     "status",
+		Short:   "show status of a local kind cluster",
+		Long:    templates.LongDesc(clusterStatusDesc),
+		Example: templates.Examples(clusterStatusExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := status.ValidateOutputFormat(rasactlFlags.Cluster.Output); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := rasaCtl.InitClusterClients(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := rasaCtl.ClusterStatus(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	addOutputFlag(cmd, &rasactlFlags.Cluster.Output)
+
+	return cmd
+}
@@ -29,6 +29,7 @@ func connectCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(connectRasaCmd())
+	cmd.AddCommand(connectActionsCmd())
 
 	return cmd
 }
@@ -0,0 +1,137 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+const (
+	connectActionsDesc = `
+Run a local action server and connect it to Rasa X deployment.
+
+The command requires a deployment that uses a local project, the action server runs custom actions from the project directory.
+It's required to have the 'rasa' command accessible by rasactl. Use the --image flag to run the action server
+as a Docker container instead, e.g. with the rasa/rasa-sdk image, the project directory is mounted in the container.
+
+The action endpoint is saved in the .endpoints.yaml file used by the 'rasactl connect rasa' command,
+and Rasa X deployment is reconfigured to use the local action server. The changes are reverted when the command exits.
+
+The command works only if Rasa X deployment runs on a local Kubernetes cluster managed with 'kind', 'k3d', or 'minikube',
+or with the Docker backend.
+`
+
+	connectActionsExample = `
+	# Connect a local action server to Rasa X deployment.
+	$ rasactl connect actions
+
+	# Run the action server on a given port.
+	$ rasactl connect actions --port 5056
+
+	# Run the action server in a Docker container.
+	$ rasactl connect actions --image rasa/rasa-sdk:2.8.2
+`
+)
+
+func connectActionsCmd() *cobra.Command {
+
+	// cmd represents the connect actions command
+	cmd := &cobra.Command{
+		Use:     "actions [DEPLOYMENT-NAME]",
+		Short:   "run a local action server and connect it to the Rasa X deployment",
+		Long:    connectActionsDesc,
+		Args:    cobra.MaximumNArgs(1),
+		Example: templates.Examples(connectActionsExample),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+
+			if rasactlFlags.ConnectActions.Image == "" && !utils.CommandExists("rasa") {
+				return xerrors.Errorf(
+					errorPrint.Sprint(
+						"The 'rasa' command doesn't exist. Check out the docs to learn how to install rasa, https://rasa.com/docs/rasa/installation/",
+					),
+				)
+			}
+
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if rasaCtl.IsDockerBackend() {
+				rasaCtl.WaitTimeout = time.Minute * 10
+				return nil
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+					ReuseValues: true,
+					Timeout:     time.Minute * 10,
+				},
+			)
+
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !rasaCtl.IsDeploymentManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			// Check if a Rasa X deployment is already installed and running
+			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if !isRunning {
+				fmt.Printf("Rasa X for the %s deployment is not running.\n", rasaCtl.Namespace)
+				return nil
+			}
+
+			if err := rasaCtl.ConnectActions(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	addConnectActionsFlags(cmd)
+
+	return cmd
+}
@@ -33,9 +33,26 @@ Connect Rasa OSS (Open Source Server) to Rasa X deployment.
 
 The command prepares a configuration that's required to connect Rasa X deployment and run a local Rasa server.
 
-It's required to have the 'rasa' command accessible by rasactl.
-
-The command works only if Rasa X deployment runs on a local Kubernetes cluster managed with 'kind'.
+It's required to have the 'rasa' command accessible by rasactl. Use the --image flag to run the Rasa server
+as a Docker container instead, the project directory and the generated configuration files are mounted in the container.
+If the image doesn't have a tag, a Rasa version compatible with the deployment is used.
+
+By default, the command works only if Rasa X deployment runs on a local Kubernetes cluster managed with 'kind', 'k3d', or 'minikube',
+the deployment is reconfigured to expose services via node ports. The changes are reverted when the command exits.
+The changes are reverted by rolling back the helm release, if the deployment is upgraded by another command in the meantime,
+e.g. 'rasactl connect actions', the rollback is skipped so that changes made by the other command are not lost.
+
+Rasa servers are restarted with backoff if they exit, the command fails if a server can't be restarted.
+The health status of each environment is saved in .rasactl/health-<environment>.json within the project directory,
+or within /tmp/rasactl-<deployment> if the deployment doesn't use a local project.
+
+Use the --watch flag to train a new model each time training data, the domain, or the configuration of the project changes.
+The model is uploaded to Rasa X and tagged as production, and the command waits until the Rasa server loads it.
+It requires a deployment that uses a local project and the credentials of a Rasa X user, see 'rasactl auth login'.
+
+Use the --port-forward flag to access Rasa X, PostgreSQL, and RabbitMQ via port forwarding instead, which works with any cluster
+and doesn't change the deployment. Ports are forwarded as long as the command is running. Rasa X can't send requests
+to the local Rasa server in this mode, the Rasa server pulls models from Rasa X and sends events to the deployment.
 `
 
 	connectRasaExample = `
@@ -47,6 +64,18 @@ The command works only if Rasa X deployment runs on a local Kubernetes cluster m
 
 	# Pass extra arguments to rasa server.
 	$ rasactl connect rasa --extra-args="--debug"
+
+	# Connect Rasa Server to a deployment in a remote cluster using port forwarding.
+	$ rasactl connect rasa --port-forward
+
+	# Run Rasa Server in a Docker container, the image tag matches the Rasa version compatible with the deployment.
+	$ rasactl connect rasa --image rasa/rasa
+
+	# Run Rasa Server in a Docker container using a given image.
+	$ rasactl connect rasa --image rasa/rasa:2.8.15-full
+
+	# Train and deploy a new model each time the project changes.
+	$ rasactl connect rasa --watch
 `
 )
 
@@ -61,7 +90,7 @@ func connectRasaCmd() *cobra.Command {
 		Example: templates.Examples(connectRasaExample),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 
-			if !utils.CommandExists("rasa") {
+			if rasactlFlags.ConnectRasa.Image == "" && !utils.CommandExists("rasa") {
 				return xerrors.Errorf(
 					errorPrint.Sprint(
 						"The 'rasa' command doesn't exist. Check out the docs to learn how to install rasa, https://rasa.com/docs/rasa/installation/",
@@ -81,6 +110,11 @@ func connectRasaCmd() *cobra.Command {
 				return err
 			}
 
+			if rasaCtl.IsDockerBackend() {
+				rasaCtl.WaitTimeout = time.Minute * 10
+				return nil
+			}
+
 			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
 			if err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
@@ -99,7 +133,7 @@ func connectRasaCmd() *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+			if !rasaCtl.IsDeploymentManageable() {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
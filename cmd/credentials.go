@@ -0,0 +1,106 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	credentialsDesc = `
+Generate the credentials configuration file that connects a Rasa server with Rasa X of a deployment.
+
+For a deployment that runs on Kubernetes, Rasa X is accessed via a local port forwarded with the 'rasactl port-forward' command,
+use the same port for both commands. For the Docker backend, the port published by the deployment is used.
+
+The configuration is printed to the standard output if the --output flag is not set.
+`
+
+	credentialsExample = `
+	# Save the credentials configuration in the credentials.yml file.
+	$ rasactl credentials -o credentials.yml
+
+	# Use Rasa X available on a given local port.
+	$ rasactl credentials --rasa-x-port 8080 -o credentials.yml
+`
+)
+
+func credentialsCmd() *cobra.Command {
+
+	// cmd represents the credentials command
+	cmd := &cobra.Command{
+		Use:     "credentials [DEPLOYMENT-NAME]",
+		Short:   "generate the credentials configuration that connects a Rasa server with a deployment",
+		Long:    templates.LongDesc(credentialsDesc),
+		Example: templates.Examples(credentialsExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if rasaCtl.IsDockerBackend() {
+				return nil
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !rasaCtl.IsDeploymentManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			if err := rasaCtl.Credentials(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	addCredentialsFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(credentialsCmd())
+}
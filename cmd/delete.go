@@ -57,10 +57,14 @@ func deleteCmd() *cobra.Command {
 				return err
 			}
 
-			if !rasaCtl.KubernetesClient.IsNamespaceManageable() && !viper.GetBool("force") {
+			if !rasaCtl.IsDeploymentManageable() && !viper.GetBool("force") {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
+			if rasaCtl.IsDockerBackend() {
+				return nil
+			}
+
 			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
 			if err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
@@ -0,0 +1,79 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+const (
+	diffDesc = `
+	Show differences between a deployment spec and the current state of the deployment.
+
+	The command lists changes that the 'rasactl apply' command would apply and prints a diff
+	of the helm manifest. No changes are applied.
+`
+
+	diffExample = `
+	# Show drift between the deployment.yaml spec and the deployment.
+	$ rasactl diff -f deployment.yaml
+`
+)
+
+func diffCmd() *cobra.Command {
+
+	// cmd represents the diff command
+	cmd := &cobra.Command{
+		Use:     "diff -f FILE",
+		Short:   "show differences between a deployment spec and a deployment",
+		Long:    templates.LongDesc(diffDesc),
+		Example: templates.Examples(diffExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.CheckHelmChartDir()
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := setDeploymentSpec(rasactlFlags.Apply.File)
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.Diff(spec); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	diffFlags(cmd)
+	//nolint:golint,errcheck
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func init() {
+
+	diffCmd := diffCmd()
+	rootCmd.AddCommand(diffCmd)
+}
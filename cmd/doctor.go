@@ -0,0 +1,80 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	doctorDesc = `
+	Check the environment used by rasactl and print diagnostics.
+
+	The command checks if Docker and the Kubernetes cluster are reachable, if the current Kubernetes context
+	is a kind, k3d, or minikube cluster, if an ingress controller is available, if the *.rasactl.localhost names resolve,
+	if Rasa OSS is installed, and if the credentials store works.
+
+	Each check reports pass, warn, or fail along with a hint on how to fix a problem.
+	The command exits with an error if at least one check fails.
+`
+
+	doctorExample = `
+	# Run diagnostic checks.
+	$ rasactl doctor
+
+	# Print results in the JSON format.
+	$ rasactl doctor -o json
+`
+)
+
+func doctorCmd() *cobra.Command {
+
+	// cmd represents the doctor command
+	cmd := &cobra.Command{
+		Use:     "doctor",
+		Short:   "check the environment and print diagnostics",
+		Long:    templates.LongDesc(doctorDesc),
+		Example: templates.Examples(doctorExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if output := rasactlFlags.Doctor.Output; output != "table" && output != "json" {
+				return xerrors.Errorf(errorPrint.Sprintf("Invalid output format: %s, use one of: json|table", output))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Failed checks are not usage errors.
+			cmd.SilenceUsage = true
+
+			if err := rasaCtl.Doctor(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+	}
+
+	doctorFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	doctorCmd := doctorCmd()
+	rootCmd.AddCommand(doctorCmd)
+}
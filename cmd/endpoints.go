@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	endpointsDesc = `
+Generate the endpoints configuration file for a Rasa server that uses services of a deployment.
+
+The configuration includes the tracker store (PostgreSQL), the event broker (RabbitMQ), the model server (Rasa X),
+the lock store (Redis), and the action endpoint. Use it to run 'rasa shell', 'rasa interactive', or your own tools
+against data of the deployment.
+
+For a deployment that runs on Kubernetes, services are accessed via local ports forwarded with the 'rasactl port-forward' command,
+use the ports printed by the command. For the Docker backend, ports published by the deployment are used.
+
+The configuration is printed to the standard output if the --output flag is not set.
+`
+
+	endpointsExample = `
+	# Forward ports to services of the deployment (run it in a separate terminal),
+	# the command prints the forwarded ports.
+	$ rasactl port-forward my-deployment
+
+	# Save the endpoints configuration in the endpoints.yml file, use ports printed by the port-forward command.
+	$ rasactl endpoints my-deployment --postgresql-port 41234 --rabbitmq-port 41235 -o endpoints.yml
+
+	# Run rasa shell against the deployment.
+	$ rasa shell --endpoints endpoints.yml
+`
+)
+
+func endpointsCmd() *cobra.Command {
+
+	// cmd represents the endpoints command
+	cmd := &cobra.Command{
+		Use:     "endpoints [DEPLOYMENT-NAME]",
+		Short:   "generate the endpoints configuration for a Rasa server that uses services of a deployment",
+		Long:    templates.LongDesc(endpointsDesc),
+		Example: templates.Examples(endpointsExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if rasaCtl.IsDockerBackend() {
+				return nil
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			if !rasaCtl.IsDeploymentManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			if err := rasaCtl.Endpoints(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	addEndpointsFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(endpointsCmd())
+}
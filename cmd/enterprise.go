@@ -25,11 +25,12 @@ func enterpriseCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:       "enterprise",
 		Short:     "manage Rasa Enterprise",
-		ValidArgs: []string{"activate", "deactivate"},
+		ValidArgs: []string{"activate", "deactivate", "status"},
 	}
 
 	cmd.AddCommand(enterpriseActivateCmd())
 	cmd.AddCommand(enterpriseDeactivateCmd())
+	cmd.AddCommand(enterpriseStatusCmd())
 
 	return cmd
 }
@@ -5,12 +5,18 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
 )
 
 func addStartUpgradeFlags(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&helmConfiguration.Timeout, "wait-timeout", time.Minute*15, "time to wait for Rasa X to be ready")
 	cmd.PersistentFlags().StringVar(&rasactlFlags.StartUpgrade.ValuesFile, "values-file", "", "absolute path to the values file")
+	cmd.Flags().StringVar(&rasactlFlags.StartUpgrade.Chart, "chart", "",
+		"the rasa-x helm chart to use instead of the helm repository: a path to a chart directory, a packaged chart (.tgz) or an OCI reference (oci://)")
+	cmd.Flags().BoolVar(&rasactlFlags.StartUpgrade.DryRun, "dry-run", false, "render the helm chart and print the manifest without applying changes")
+	cmd.Flags().BoolVar(&rasactlFlags.StartUpgrade.Diff, "diff", false,
+		"render the helm chart and print a diff against the current deployment without applying changes")
 }
 
 func addStartFlags(cmd *cobra.Command) {
@@ -18,7 +24,7 @@ func addStartFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&helmConfiguration.Version, "rasa-x-chart-version", types.HelmChartVersionRasaX, "a helm chart version to use")
 
 	cmd.PersistentFlags().StringVar(&rasactlFlags.Start.ProjectPath, "project-path", "",
-		"absolute path to the project directory mounted in kind")
+		"absolute path to the project directory, mounted in a local cluster (kind, k3d, or minikube) or synced into a volume in a remote cluster")
 
 	cmd.PersistentFlags().BoolVarP(&rasactlFlags.Start.Project, "project", "p", false,
 		"use the current working directory as a project directory, the flag is ignored if --project-path is used")
@@ -26,12 +32,23 @@ func addStartFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&rasactlFlags.Start.RasaXPassword, "rasa-x-password", "rasaxlocal", "Rasa X password")
 	cmd.PersistentFlags().BoolVar(&rasactlFlags.Start.RasaXPasswordStdin, "rasa-x-password-stdin", false, "read the Rasa X password from stdin")
 	cmd.Flags().BoolVar(&rasactlFlags.Start.UseEdgeRelease, "rasa-x-edge-release", false, "use the latest edge release of Rasa X")
+	cmd.Flags().BoolVar(&rasactlFlags.Start.WithRasaServer, "with-rasa-server", false,
+		"deploy Rasa servers (rasa production and rasa worker) in the deployment, see 'rasactl rasa enable --help'")
+	cmd.Flags().StringVar(&rasactlFlags.Start.RasaVersion, "rasa-version", "",
+		"a Rasa version used with the --with-rasa-server flag, a version compatible with Rasa X is used if empty")
+	cmd.Flags().StringVar(&rasactlFlags.Start.Bundle, "bundle", "",
+		"path to an air-gapped bundle created by the 'rasactl bundle create' command, the flag is supported only with kind, k3d, and minikube")
 	cmd.Flags().BoolVar(&rasactlFlags.Start.Create, "create", false,
 		"create a new deployment. If --project or --project-path is set, or there is no existing deployment,"+
 			" the flag is not required to create a new deployment")
 
 }
 
+func addRasaEnableFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&rasactlFlags.Rasa.Enable.RasaVersion, "rasa-version", "",
+		"a Rasa version to deploy, a version compatible with Rasa X is used if empty")
+}
+
 func addUpgradeFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&helmConfiguration.Atomic, "atomic", false, "if set, upgrade process rolls back changes made in case of failed upgrade")
 	cmd.Flags().StringVar(&helmConfiguration.Version, "rasa-x-chart-version", "", "a helm chart version to use")
@@ -47,8 +64,13 @@ func addDeleteFlags(cmd *cobra.Command) {
 func addStatusFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVarP(&rasactlFlags.Status.Details, "details", "d", false,
 		"show detailed information, such as running pods, helm chart status")
-	cmd.PersistentFlags().StringVarP(&rasactlFlags.Status.Output, "output", "o", "table",
-		"output format. One of: json|table")
+	addOutputFlag(cmd, &rasactlFlags.Status.Output)
+	cmd.PersistentFlags().BoolVarP(&rasactlFlags.Status.Watch, "watch", "w", false,
+		"keep refreshing the status until interrupted")
+}
+
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", "table", "output format. One of: "+status.OutputFormats)
 }
 
 func addAddFlags(cmd *cobra.Command) {
@@ -60,6 +82,46 @@ func addConnectRasaFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&rasactlFlags.ConnectRasa.RunSeparateWorker, "run-separate-worker", false,
 		"runs a separate Rasa server for the worker environment")
 	cmd.Flags().StringSliceVar(&rasactlFlags.ConnectRasa.ExtraArgs, "extra-args", nil, "extra arguments for Rasa server")
+	cmd.Flags().BoolVar(&rasactlFlags.ConnectRasa.PortForward, "port-forward", false,
+		"use port forwarding to access services of the deployment, the configuration of the deployment is not changed")
+	cmd.Flags().StringVar(&rasactlFlags.ConnectRasa.Image, "image", "",
+		"run the Rasa server in a Docker container with a given image, if the tag is not set a Rasa version compatible with the deployment is used")
+	cmd.Flags().BoolVar(&rasactlFlags.ConnectRasa.Watch, "watch", false,
+		"watch the project for changes, train a new model and deploy it to the connected Rasa server")
+}
+
+func addConnectActionsFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&rasactlFlags.ConnectActions.Port, "port", "p", 5055, "port to run the action server at")
+	cmd.Flags().StringVar(&rasactlFlags.ConnectActions.Image, "image", "",
+		"run the action server in a Docker container with a given image, e.g. rasa/rasa-sdk:2.8.2")
+}
+
+func addPortForwardFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&rasactlFlags.PortForward.RasaXPort, "rasa-x-port", 5002,
+		"local port forwarded to Rasa X, a random port is used if 0 or if the port is in use")
+	cmd.Flags().IntVar(&rasactlFlags.PortForward.PostgreSQLPort, "postgresql-port", 0,
+		"local port forwarded to PostgreSQL, a random port is used if not set or if the port is in use")
+	cmd.Flags().IntVar(&rasactlFlags.PortForward.RabbitMQPort, "rabbitmq-port", 0,
+		"local port forwarded to RabbitMQ, a random port is used if not set or if the port is in use")
+	cmd.Flags().IntVar(&rasactlFlags.PortForward.RedisPort, "redis-port", 0,
+		"local port forwarded to Redis, a random port is used if not set or if the port is in use")
+	cmd.Flags().IntVar(&rasactlFlags.PortForward.ActionServerPort, "action-server-port", 0,
+		"local port forwarded to the action server, a random port is used if not set or if the port is in use")
+}
+
+func addEndpointsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Endpoints.Output, "output", "o", "", "save the configuration in a given file")
+	cmd.Flags().IntVar(&rasactlFlags.Endpoints.RasaXPort, "rasa-x-port", 5002, "local port forwarded to Rasa X")
+	cmd.Flags().IntVar(&rasactlFlags.Endpoints.PostgreSQLPort, "postgresql-port", 5432, "local port forwarded to PostgreSQL")
+	cmd.Flags().IntVar(&rasactlFlags.Endpoints.RabbitMQPort, "rabbitmq-port", 5672, "local port forwarded to RabbitMQ")
+	cmd.Flags().IntVar(&rasactlFlags.Endpoints.RedisPort, "redis-port", 6379, "local port forwarded to Redis")
+	cmd.Flags().IntVar(&rasactlFlags.Endpoints.ActionServerPort, "action-server-port", 5055,
+		"local port forwarded to the action server, or a port of a local action server for the Docker backend")
+}
+
+func addCredentialsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Credentials.Output, "output", "o", "", "save the configuration in a given file")
+	cmd.Flags().IntVar(&rasactlFlags.Credentials.RasaXPort, "rasa-x-port", 5002, "local port forwarded to Rasa X")
 }
 
 func addAuthLoginFlags(cmd *cobra.Command) {
@@ -83,4 +145,87 @@ func logsFlags(cmd *cobra.Command) {
 		"the container in a pod if it exists")
 	cmd.PersistentFlags().Int64Var(&rasactlFlags.Logs.TailLines, "tail", -1, "lines of recent log file to display. Defaults to -1 showing all log lines")
 	cmd.PersistentFlags().StringVarP(&rasactlFlags.Logs.Container, "container", "c", "", "a container name")
+	cmd.PersistentFlags().BoolVar(&rasactlFlags.Logs.All, "all", false, "print the logs for all pods and containers of a deployment")
+	cmd.PersistentFlags().StringVarP(&rasactlFlags.Logs.Selector, "selector", "l", "",
+		"print the logs for all pods that match a label selector, e.g. app.kubernetes.io/component=rasa-x")
+	cmd.PersistentFlags().DurationVar(&rasactlFlags.Logs.Since, "since", 0, "only return logs newer than a relative duration like 5s, 2m, or 3h")
+	cmd.PersistentFlags().StringVar(&rasactlFlags.Logs.Grep, "grep", "", "only print log lines that match a regular expression")
+	cmd.PersistentFlags().BoolVar(&rasactlFlags.Logs.Timestamps, "timestamps", false, "include timestamps on each line in the log output")
+}
+
+func backupFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Backup.File, "output", "o", "", "path to the backup file (default \"<DEPLOYMENT-NAME>-<TIMESTAMP>.tar.gz\")")
+	cmd.Flags().BoolVar(&rasactlFlags.Backup.SkipModels, "skip-models", false, "don't include models in the backup")
+}
+
+func restoreFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Restore.File, "file", "f", "", "path to the backup file")
+	cmd.Flags().BoolVar(&rasactlFlags.Restore.SkipModels, "skip-models", false, "don't restore models")
+	cmd.Flags().BoolVar(&rasactlFlags.Restore.SkipValues, "skip-values", false,
+		"don't restore helm values, use it to restore data into a deployment with different configuration")
+	cmd.Flags().StringVar(&rasactlFlags.Restore.ChartVersion, "rasa-x-chart-version", "",
+		"a helm chart version used to restore helm values, the version stored in the backup is used if empty")
+	cmd.Flags().DurationVar(&helmConfiguration.Timeout, "wait-timeout", time.Minute*15, "time to wait for Rasa X to be ready")
+}
+
+func historyFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&rasactlFlags.History.Max, "max", 256, "maximum number of revisions to include in history")
+}
+
+func rollbackFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&helmConfiguration.Timeout, "wait-timeout", time.Minute*15, "time to wait for Rasa X to be ready")
+}
+
+func bundleCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Bundle.Create.File, "output", "o", "",
+		"path to the bundle file (default \"rasactl-bundle-<RASA-X-CHART-VERSION>.tar.gz\")")
+	cmd.Flags().StringVar(&helmConfiguration.ReleaseName, "rasa-x-release-name", "rasa-x", "a helm release name used to render the helm chart")
+	cmd.Flags().StringVar(&helmConfiguration.Version, "rasa-x-chart-version", types.HelmChartVersionRasaX, "a helm chart version to use")
+	cmd.Flags().StringVar(&rasactlFlags.StartUpgrade.ValuesFile, "values-file", "", "absolute path to the values file")
+	cmd.Flags().StringVar(&rasactlFlags.StartUpgrade.Chart, "chart", "",
+		"the rasa-x helm chart to use instead of the helm repository: a path to a chart directory, a packaged chart (.tgz) or an OCI reference (oci://)")
+	cmd.Flags().BoolVar(&rasactlFlags.Start.UseEdgeRelease, "rasa-x-edge-release", false, "use the latest edge release of Rasa X")
+	cmd.Flags().BoolVar(&rasactlFlags.Start.WithRasaServer, "with-rasa-server", false,
+		"include images of Rasa servers deployed with the 'rasactl start --with-rasa-server' command")
+	cmd.Flags().StringVar(&rasactlFlags.Start.RasaVersion, "rasa-version", "", "a Rasa version used with the --with-rasa-server flag")
+}
+
+func applyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Apply.File, "file", "f", "", "path to the deployment spec file")
+	cmd.Flags().StringVar(&rasactlFlags.Start.RasaXPassword, "rasa-x-password", "rasaxlocal", "Rasa X password used if a deployment is created")
+	cmd.Flags().BoolVar(&rasactlFlags.Start.RasaXPasswordStdin, "rasa-x-password-stdin", false, "read the Rasa X password from stdin")
+	cmd.Flags().DurationVar(&helmConfiguration.Timeout, "wait-timeout", time.Minute*15, "time to wait for Rasa X to be ready")
+}
+
+func diffFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Apply.File, "file", "f", "", "path to the deployment spec file")
+}
+
+func doctorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.Doctor.Output, "output", "o", "table", "output format. One of: json|table")
+}
+
+func supportBundleFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&rasactlFlags.SupportBundle.File, "output", "o", "",
+		"path to the support bundle file (default \"<DEPLOYMENT-NAME>-support-bundle-<TIMESTAMP>.tar.gz\")")
+	cmd.Flags().StringVar(&helmConfiguration.ReleaseName, "rasa-x-release-name", "rasa-x",
+		"a helm release name, used if the deployment state is not available")
+}
+
+func clusterFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&rasactlFlags.Cluster.Name, "name", "rasactl", "a name of the kind cluster")
+}
+
+func clusterCreateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&rasactlFlags.Cluster.Image, "image", "", "a kind node image to use, the default image of kind is used if empty")
+	cmd.Flags().DurationVar(&rasactlFlags.Cluster.WaitTimeout, "wait-timeout", time.Minute*5, "time to wait for the cluster components to be ready")
+}
+
+func clusterDeleteFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&rasactlFlags.Cluster.Yes, "yes", "y", false, "delete the cluster without confirmation")
+}
+
+func modelUploadFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&rasactlFlags.Model.Upload.Timeout, "timeout", time.Minute*30,
+		"time to wait for the upload to finish, including retries, 0 means no timeout")
 }
@@ -0,0 +1,96 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	historyDesc = `
+Show revisions of a deployment.
+
+Each upgrade of a deployment creates a new revision of the helm release, rasactl keeps up to 10 revisions.
+A revision can be used with the 'rasactl rollback' command.
+`
+
+	historyExample = `
+	# Show revisions of the 'my-deployment' deployment.
+	$ rasactl history my-deployment
+
+	# Show the last 3 revisions (use the currently active deployment).
+	$ rasactl history --max 3
+`
+)
+
+func historyCmd() *cobra.Command {
+
+	// cmd represents the history command
+	cmd := &cobra.Command{
+		Use:     "history [DEPLOYMENT-NAME]",
+		Short:   "show revisions of a deployment",
+		Long:    templates.LongDesc(historyDesc),
+		Example: templates.Examples(historyExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := rasaCtl.History(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	historyFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	historyCmd := historyCmd()
+	rootCmd.AddCommand(historyCmd)
+}
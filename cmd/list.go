@@ -18,6 +18,9 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
 )
 
 const (
@@ -35,6 +38,17 @@ A deployment is marked as 'CURRENT' if:
 	- a default deployment is defined, e.g. via the 'rasactl config use-deployment' command.
   - there is only one deployment.
 
+`
+
+	listExample = `
+	# List all deployments.
+	$ rasactl list
+
+	# List all deployments in the JSON format.
+	$ rasactl list -o json
+
+	# Print names of running deployments.
+	$ rasactl list -o go-template='{{range .deployments}}{{if eq .status "Running"}}{{.name}}{{"\n"}}{{end}}{{end}}'
 `
 )
 
@@ -45,9 +59,14 @@ func listCmd() *cobra.Command {
 		Use:     "list",
 		Short:   "list deployments",
 		Long:    listDesc,
+		Example: templates.Examples(listExample),
 		Aliases: []string{"ls"},
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := status.ValidateOutputFormat(rasactlFlags.List.Output); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
 			if _, err := parseArgs(namespace, args, 0, 0, rasactlFlags); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
@@ -60,6 +79,8 @@ func listCmd() *cobra.Command {
 		},
 	}
 
+	addOutputFlag(cmd, &rasactlFlags.List.Output)
+
 	return cmd
 }
 
@@ -29,6 +29,11 @@ const (
 	logsDesc = `
 Print the logs for a container in a pod. If the pod has only one container, the container name is
 optional.
+
+Use the --all flag to print the logs for all pods of a deployment, or the --selector flag to print the logs
+for pods that match a label selector. Logs from all matching pods and containers are merged into
+one stream, each line is prefixed with a pod and container name. In the follow mode, pods that
+are created or restarted later are picked up automatically.
 `
 
 	logsExample = `
@@ -49,6 +54,15 @@ optional.
 
 	# Begin streaming the logs from pod rasa-x
   $ rasactl logs -f rasa-x
+
+	# Stream the logs from all pods of the 'my-deployment' deployment.
+	$ rasactl logs my-deployment --all -f
+
+	# Stream the logs from the rasa-x pods from the last 10 minutes.
+	$ rasactl logs -l app.kubernetes.io/component=rasa-x --since 10m -f
+
+	# Print only log lines that contain errors, with timestamps.
+	$ rasactl logs --all --grep 'ERROR|Exception' --timestamps
 `
 )
 
@@ -72,10 +86,24 @@ func logsCmd() *cobra.Command {
 			}
 			parsedArgs = args
 
+			if rasactlFlags.Logs.All || rasactlFlags.Logs.Selector != "" {
+				if parsedArgs[1] != "" {
+					return xerrors.Errorf(errorPrint.Sprint("A pod name can't be used together with the --all or --selector flag"))
+				}
+
+				if rasactlFlags.Logs.Previous && rasactlFlags.Logs.Follow {
+					return xerrors.Errorf(errorPrint.Sprint("The --previous flag can't be used together with --follow for multiple pods"))
+				}
+			}
+
 			if err := checkIfNamespaceExists(); err != nil {
 				return err
 			}
 
+			if rasaCtl.IsDockerBackend() {
+				return nil
+			}
+
 			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
 			if err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
@@ -101,7 +129,7 @@ func logsCmd() *cobra.Command {
 				return nil
 			}
 
-			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+			if !rasaCtl.IsDeploymentManageable() {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
@@ -22,6 +22,7 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
 )
 
@@ -36,6 +37,12 @@ const (
 
 	# List all models for the 'my-deployment' deployment.
 	$ rasactl model list my-deployment
+
+	# List all models in the YAML format.
+	$ rasactl model list -o yaml
+
+	# Print names of all models.
+	$ rasactl model list -o jsonpath='{.models[*].name}'
 `
 )
 
@@ -49,6 +56,10 @@ func modelListCmd() *cobra.Command {
 		Args:    cobra.MaximumNArgs(1),
 		Aliases: []string{"ls"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := status.ValidateOutputFormat(rasactlFlags.Model.List.Output); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
 			if err := checkIfDeploymentsExist(); err != nil {
 				return err
 			}
@@ -99,5 +110,7 @@ func modelListCmd() *cobra.Command {
 		},
 	}
 
+	addOutputFlag(cmd, &rasactlFlags.Model.List.Output)
+
 	return cmd
 }
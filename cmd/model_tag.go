@@ -104,7 +104,7 @@ func modelTagCmd() *cobra.Command {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
-			if err := rasaCtl.ModelTag(); err != nil {
+			if err := rasaCtl.ModelTag(rasactlFlags.Model.Tag.Model, rasactlFlags.Model.Tag.Name); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
 
@@ -28,6 +28,9 @@ import (
 const (
 	modelUploadDesc = `
 Upload a model to Rasa X / Enterprise.
+
+The model file is streamed to Rasa X. The upload is retried with a backoff if it fails
+because of a connection error or a server error (5xx).
 `
 
 	modelUploadExample = `
@@ -36,6 +39,9 @@ Upload a model to Rasa X / Enterprise.
 
 	# Upload the model.tar.gz model file to the 'my-deployment' deployment.
 	$ rasactl model upload my-deployment model.tag.gz
+
+	# Upload a large model file, wait up to 2 hours for the upload to finish.
+	$ rasactl model upload model.tar.gz --timeout 2h
 `
 )
 
@@ -93,7 +99,7 @@ func modelUploadCmd() *cobra.Command {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
-			if err := rasaCtl.ModelUpload(); err != nil {
+			if err := rasaCtl.ModelUpload(rasactlFlags.Model.Upload.File, rasactlFlags.Model.Upload.Timeout); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
 
@@ -101,5 +107,7 @@ func modelUploadCmd() *cobra.Command {
 		},
 	}
 
+	modelUploadFlags(cmd)
+
 	return cmd
 }
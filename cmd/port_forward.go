@@ -0,0 +1,119 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	portForwardDesc = `
+Forward local ports to the Rasa X, PostgreSQL, RabbitMQ, Redis, and action server services of a deployment.
+Redis and the action server are skipped if they are not installed by the helm chart.
+
+Ports are forwarded to pods of the services until the command is interrupted,
+the port forwarding is restarted if a pod is restarted. The deployment is not changed.
+
+Rasa X is forwarded to the same local port as the one used by the 'rasactl endpoints' and 'rasactl credentials' commands,
+other services are forwarded to random local ports. A random port is also used if a given port is already in use.
+The forwarded ports are printed together with the 'rasactl endpoints' command that uses them.
+`
+
+	portForwardExample = `
+	# Forward local ports to services of the 'my-deployment' deployment.
+	$ rasactl port-forward my-deployment
+
+	# Use a random local port for Rasa X.
+	$ rasactl port-forward my-deployment --rasa-x-port 0
+
+	# Forward PostgreSQL to the 5432 local port.
+	$ rasactl port-forward my-deployment --postgresql-port 5432
+`
+)
+
+func portForwardCmd() *cobra.Command {
+
+	// cmd represents the port-forward command
+	cmd := &cobra.Command{
+		Use:     "port-forward [DEPLOYMENT-NAME]",
+		Short:   "forward local ports to services of a deployment",
+		Long:    templates.LongDesc(portForwardDesc),
+		Example: templates.Examples(portForwardExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			rasaCtl.HelmClient.SetConfiguration(
+				&types.HelmConfigurationSpec{
+					ReleaseName: string(stateData[types.StateHelmReleaseName]),
+				},
+			)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.IsDeploymentManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			// Check if a Rasa X deployment is running
+			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if !isRunning {
+				fmt.Printf("The %s deployment is not running.\n", rasaCtl.Namespace)
+				return nil
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.PortForward(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+	}
+
+	addPortForwardFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(portForwardCmd())
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	restoreDesc = `
+Restore a deployment from a backup created with the 'rasactl backup' command.
+
+The command restores helm values, the deployment state, the PostgreSQL databases and models. The deployment has to be running.
+
+Helm values are restored with the helm chart version stored in the backup,
+use the --rasa-x-chart-version flag to use a different version.
+
+Helm values stored in a backup include deployment specific configuration, e.g. the ingress host.
+Use the --skip-values flag if you restore a backup into a different deployment.
+`
+
+	restoreExample = `
+	# Restore the 'my-deployment' deployment from a backup.
+	$ rasactl restore my-deployment -f my-deployment.tar.gz
+
+	# Restore only data into the 'other-deployment' deployment.
+	$ rasactl restore other-deployment -f my-deployment.tar.gz --skip-values
+`
+)
+
+func restoreCmd() *cobra.Command {
+
+	// cmd represents the restore command
+	cmd := &cobra.Command{
+		Use:     "restore [DEPLOYMENT-NAME]",
+		Short:   "restore a deployment from a backup",
+		Long:    templates.LongDesc(restoreDesc),
+		Example: templates.Examples(restoreExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
+			helmConfiguration.Version = string(stateData[types.StateHelmChartVersion])
+			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			// Check if a Rasa X deployment is running
+			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if !isRunning {
+				fmt.Printf("The %s deployment is not running.\n", rasaCtl.Namespace)
+				return nil
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.Restore(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	restoreFlags(cmd)
+	//nolint:golint,errcheck
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func init() {
+
+	restoreCmd := restoreCmd()
+	rootCmd.AddCommand(restoreCmd)
+}
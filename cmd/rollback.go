@@ -0,0 +1,125 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	rollbackDesc = `
+Roll back a deployment to a previous revision.
+
+If a revision is not specified, the deployment is rolled back to the previous revision.
+Use the 'rasactl history' command to see available revisions.
+`
+
+	rollbackExample = `
+	# Roll back the 'my-deployment' deployment to the previous revision.
+	$ rasactl rollback my-deployment
+
+	# Roll back to revision 3 (use the currently active deployment).
+	$ rasactl rollback 3
+
+	# Roll back the 'my-deployment' deployment to revision 3.
+	$ rasactl rollback my-deployment 3
+`
+)
+
+func rollbackCmd() *cobra.Command {
+
+	// cmd represents the rollback command
+	cmd := &cobra.Command{
+		Use:     "rollback [DEPLOYMENT-NAME] [REVISION]",
+		Short:   "roll back a deployment to a previous revision",
+		Long:    templates.LongDesc(rollbackDesc),
+		Example: templates.Examples(rollbackExample),
+		Args:    cobra.RangeArgs(0, 2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			args, err := parseArgs(namespace, args, 1, 2, rasactlFlags)
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if len(args) > 1 && args[1] != "" {
+				revision, err := strconv.Atoi(args[1])
+				if err != nil || revision < 1 {
+					return xerrors.Errorf(errorPrint.Sprintf("Invalid revision: %s, the revision has to be a positive number", args[1]))
+				}
+				rasactlFlags.Rollback.Revision = revision
+			}
+
+			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
+			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(string(stateData[types.StateHelmReleaseName]))
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			// Check if a Rasa X deployment is running
+			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
+			if err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if !isRunning {
+				fmt.Printf("The %s deployment is not running.\n", rasaCtl.Namespace)
+				return nil
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.Rollback(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	rollbackFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	rollbackCmd := rollbackCmd()
+	rootCmd.AddCommand(rollbackCmd)
+}
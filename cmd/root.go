@@ -45,6 +45,34 @@ var (
 	rasactlFlags      *types.RasaCtlFlags = &types.RasaCtlFlags{}
 )
 
+// dockerBackendCommands defines commands that support the docker backend.
+var dockerBackendCommands = map[string]bool{
+	"rasactl start":           true,
+	"rasactl stop":            true,
+	"rasactl status":          true,
+	"rasactl logs":            true,
+	"rasactl delete":          true,
+	"rasactl connect rasa":    true,
+	"rasactl connect actions": true,
+	"rasactl endpoints":       true,
+	"rasactl credentials":     true,
+}
+
+// checkBackend validates the backend type and checks if a given command supports it.
+func checkBackend(cmd *cobra.Command) error {
+	switch backend := types.BackendType(viper.GetString("backend")); backend {
+	case types.BackendKubernetes:
+		return nil
+	case types.BackendDocker:
+		if !dockerBackendCommands[cmd.CommandPath()] {
+			return xerrors.Errorf(errorPrint.Sprintf("The '%s' command is not supported with the %s backend", cmd.CommandPath(), backend))
+		}
+		return nil
+	default:
+		return xerrors.Errorf(errorPrint.Sprintf("Unknown backend '%s', use one of: %s|%s", backend, types.BackendKubernetes, types.BackendDocker))
+	}
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:     "rasactl",
@@ -59,7 +87,14 @@ var rootCmd = &cobra.Command{
 			Flags: rasactlFlags,
 		}
 
-		if !strings.Contains(cmd.CommandPath(), "help") && !strings.Contains(cmd.CommandPath(), "completion") {
+		// The doctor command initializes clients on its own to report problems with each of them,
+		// and the cluster commands work without access to a Kubernetes cluster.
+		if !strings.Contains(cmd.CommandPath(), "help") && !strings.Contains(cmd.CommandPath(), "completion") &&
+			cmd.Name() != "doctor" && !(cmd.HasParent() && cmd.Parent().Name() == "cluster") {
+			if err := checkBackend(cmd); err != nil {
+				return err
+			}
+
 			if err := rasaCtl.InitClients(); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
@@ -87,6 +122,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&rasactlFlags.Global.Debug, "debug", false, "enable debug output")
 	rootCmd.PersistentFlags().String("kubeconfig", filepath.Join(home, ".kube", "config"), "absolute path to the kubeconfig file")
 	rootCmd.PersistentFlags().String("kube-context", "", "name of the kubeconfig context to use")
+	rootCmd.PersistentFlags().String("backend", string(types.BackendKubernetes),
+		"backend used to run deployments. One of: kubernetes|docker")
 
 	//nolint:golint,errcheck
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
@@ -96,6 +133,8 @@ func init() {
 	viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
 	//nolint:golint,errcheck
 	viper.BindPFlag("kube-context", rootCmd.PersistentFlags().Lookup("kube-context"))
+	//nolint:golint,errcheck
+	viper.BindPFlag("backend", rootCmd.PersistentFlags().Lookup("backend"))
 }
 
 func initLog() {
@@ -16,6 +16,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -30,11 +31,20 @@ const (
 	startDesc = `This command creates a Rasa X deployment or starts a stopped deployment if a given deployment already exists.
 
 If the --project or --project-path is used, a Rasa X deployment will be using a local directory with Rasa project.
+With a local cluster (kind, k3d, or minikube) the directory is mounted into the cluster. With a remote cluster
+the directory is synced into a persistent volume by a helper pod, and the command doesn't exit, it keeps syncing
+changes until it's interrupted, use the 'rasactl sync' command to resume syncing.
 
 If a deployment name is not defined, a random name is generated and used as a deployment name.
 
 If there is no existing deployment or you use the --project or --project-path flag a new deployment will be created,
 otherwise, you have to use the --create flags to create a deployment.
+
+Use the --with-rasa-server flag to deploy Rasa servers (rasa production and rasa worker) in the cluster
+with a Rasa version compatible with Rasa X, see 'rasactl rasa enable --help'.
+
+Use the --dry-run flag to print the rendered manifest, or the --diff flag to print a diff against the current deployment.
+No changes are applied if one of the flags is used. Values of secrets are redacted in the output of both flags.
 `
 
 	startExample = `
@@ -57,6 +67,18 @@ otherwise, you have to use the --create flags to create a deployment.
 	# Create a Rasa X deployment that uses a local Rasa project.
 	# The command is executed in a Rasa project directory.
 	$ rasactl start --project
+
+	# Create a Rasa X deployment with Rasa servers running in the cluster.
+	$ rasactl start --with-rasa-server
+
+	# Create a Rasa X deployment using a local copy of the rasa-x helm chart.
+	$ rasactl start --chart ./rasa-x-4.3.3.tgz
+
+	# Create a Rasa X deployment from an air-gapped bundle (see 'rasactl bundle create --help').
+	$ rasactl start my-deployment --bundle rasactl-bundle-4.3.3.tar.gz
+
+	# Print the manifest that would be deployed without creating a deployment.
+	$ rasactl start my-deployment --values-file custom-configuration.yaml --dry-run
 `
 )
 
@@ -88,7 +110,7 @@ func startCmd() *cobra.Command {
 			}
 
 			// Get list of namespaces (deployments)
-			namespaces, err := rasaCtl.KubernetesClient.GetNamespaces()
+			namespaces, err := rasaCtl.GetDeployments()
 			if err != nil {
 				return xerrors.Errorf(errorPrint.Sprint(err))
 			}
@@ -102,7 +124,9 @@ func startCmd() *cobra.Command {
 				}
 			}
 
-			if rasaCtl.KubernetesClient.IsSecretWithStateExist() {
+			if rasaCtl.IsDockerBackend() {
+				rasaCtl.WaitTimeout = helmConfiguration.Timeout
+			} else if rasaCtl.KubernetesClient.IsSecretWithStateExist() {
 				stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
 				if err != nil {
 					return xerrors.Errorf(errorPrint.Sprintf("%s", err))
@@ -111,8 +135,10 @@ func startCmd() *cobra.Command {
 				helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
 			}
 
-			rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
-			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+			if !rasaCtl.IsDockerBackend() {
+				rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
+				rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+			}
 
 			_, isRunning, err := rasaCtl.CheckDeploymentStatus()
 			if err != nil {
@@ -120,13 +146,19 @@ func startCmd() *cobra.Command {
 			}
 
 			if isRunning {
-				fmt.Printf("Rasa X is already running in the %s namespace.\n", rasaCtl.HelmClient.GetNamespace())
+				fmt.Printf("Rasa X is already running in the %s deployment.\n", rasaCtl.Namespace)
 				return nil
 			}
 			defer rasaCtl.Spinner.Stop()
 			if err := rasaCtl.Start(); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
+
+			// Changes in a project synced with a remote cluster are synced until the command is interrupted.
+			rasaCtl.Spinner.Stop()
+			if err := rasaCtl.WatchSyncedProject(context.Background()); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
 			return nil
 		},
 	}
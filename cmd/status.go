@@ -20,12 +20,16 @@ import (
 	"golang.org/x/xerrors"
 	"k8s.io/kubectl/pkg/util/templates"
 
+	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
 )
 
 const (
 	statusDesc = `
 Show the status of a deployment.
+
+Use the --watch flag to keep refreshing the status, the pod table, Rasa versions,
+and the progress of the database migration until the command is interrupted.
 `
 
 	statusExample = `
@@ -35,6 +39,15 @@ Show the status of a deployment.
 	# Show status for the 'example' deployment along with details.
 	$ rasactl status example --details
 
+	# Keep refreshing status for the 'example' deployment, press Ctrl+C to exit.
+	$ rasactl status example --watch
+
+	# Show status for the 'example' deployment along with pods in the YAML format.
+	$ rasactl status example --details -o yaml
+
+	# Print the Rasa X URL for the 'example' deployment.
+	$ rasactl status example -o jsonpath='{.url}'
+
 `
 )
 
@@ -48,6 +61,10 @@ func statusCmd() *cobra.Command {
 		Example: templates.Examples(statusExample),
 		Args:    cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := status.ValidateOutputFormat(rasactlFlags.Status.Output); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
 			if err := checkIfDeploymentsExist(); err != nil {
 				return err
 			}
@@ -60,20 +77,22 @@ func statusCmd() *cobra.Command {
 			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+			if !rasaCtl.IsDeploymentManageable() {
 				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
 			}
 
-			if rasaCtl.KubernetesClient.IsSecretWithStateExist() {
-				stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
-				if err != nil {
-					return xerrors.Errorf(errorPrint.Sprintf("%s", err))
-				}
+			if !rasaCtl.IsDockerBackend() {
+				if rasaCtl.KubernetesClient.IsSecretWithStateExist() {
+					stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+					if err != nil {
+						return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+					}
 
-				helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
+					helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
+				}
+				rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
+				rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
 			}
-			rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
-			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
 
 			if err := rasaCtl.Status(); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
@@ -65,6 +65,10 @@ func stopCmd() *cobra.Command {
 				return err
 			}
 
+			if rasaCtl.IsDockerBackend() {
+				return nil
+			}
+
 			stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
 			if err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
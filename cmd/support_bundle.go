@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	supportBundleDesc = `
+Collect diagnostic information about a deployment into a support bundle.
+
+The support bundle is a gzip-compressed tar archive that includes pod descriptions, Kubernetes events,
+container logs (including previous instances of restarted containers), the helm release manifest and values,
+the rasactl state and responses from the Rasa X /api/health and /api/version endpoints.
+
+Sensitive values, such as passwords, tokens and data of secrets, are redacted.
+The command works for deployments that failed to start, data that can't be collected is listed
+in the errors.txt file in the bundle.
+`
+
+	supportBundleExample = `
+	# Collect a support bundle for the 'my-deployment' deployment.
+	$ rasactl support-bundle my-deployment
+
+	# Save the support bundle to a given file (use the currently active deployment).
+	$ rasactl support-bundle -o support-bundle.tar.gz
+`
+)
+
+func supportBundleCmd() *cobra.Command {
+
+	// cmd represents the support-bundle command
+	cmd := &cobra.Command{
+		Use:     "support-bundle [DEPLOYMENT-NAME]",
+		Short:   "collect diagnostic information about a deployment",
+		Long:    templates.LongDesc(supportBundleDesc),
+		Example: templates.Examples(supportBundleExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			if err := checkIfNamespaceExists(); err != nil {
+				return err
+			}
+
+			if rasactlFlags.SupportBundle.File == "" {
+				rasactlFlags.SupportBundle.File = fmt.Sprintf("%s-support-bundle-%s.tar.gz",
+					rasaCtl.Namespace, time.Now().Format("20060102150405"))
+			}
+
+			if rasaCtl.KubernetesClient.IsSecretWithStateExist() {
+				stateData, err := rasaCtl.KubernetesClient.ReadSecretWithState()
+				if err != nil {
+					return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+				}
+
+				helmConfiguration.ReleaseName = string(stateData[types.StateHelmReleaseName])
+			}
+			rasaCtl.HelmClient.SetConfiguration(helmConfiguration)
+			rasaCtl.KubernetesClient.SetHelmReleaseName(helmConfiguration.ReleaseName)
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.KubernetesClient.IsNamespaceManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.SupportBundle(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return nil
+		},
+	}
+
+	supportBundleFlags(cmd)
+
+	return cmd
+}
+
+func init() {
+
+	supportBundleCmd := supportBundleCmd()
+	rootCmd.AddCommand(supportBundleCmd)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	syncDesc = `
+Sync a local project with a deployment that runs in a remote Kubernetes cluster.
+
+The project directory is uploaded into the deployment volume by a helper pod, and changes
+are synced until the command is interrupted. The command is used by deployments created with
+the --project or --project-path flag in a cluster other than kind, k3d, or minikube.
+`
+
+	syncExample = `
+	# Sync a local project with the 'my-deployment' deployment.
+	$ rasactl sync my-deployment
+`
+)
+
+func syncCmd() *cobra.Command {
+
+	// cmd represents the sync command
+	cmd := &cobra.Command{
+		Use:     "sync [DEPLOYMENT-NAME]",
+		Short:   "sync a local project with a deployment in a remote cluster",
+		Long:    templates.LongDesc(syncDesc),
+		Example: templates.Examples(syncExample),
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkIfDeploymentsExist(); err != nil {
+				return err
+			}
+
+			if _, err := parseArgs(namespace, args, 1, 1, rasactlFlags); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+
+			return checkIfNamespaceExists()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !rasaCtl.IsDeploymentManageable() {
+				return xerrors.Errorf(errorPrint.Sprintf("The %s namespace exists but is not managed by rasactl, can't continue :(", rasaCtl.Namespace))
+			}
+
+			defer rasaCtl.Spinner.Stop()
+			if err := rasaCtl.SyncProject(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd())
+}
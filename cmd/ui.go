@@ -0,0 +1,80 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	uiDesc = `
+	Run a full-screen terminal dashboard for rasactl deployments.
+
+	The dashboard lists deployments with their status, and for the selected deployment
+	it shows pods, recent logs, and models. Deployments are refreshed every few seconds.
+
+	Key bindings:
+
+	* s - start the selected deployment
+	* x - stop the selected deployment
+	* o - open Rasa X in a web browser
+	* l - tail logs of the selected pod
+	* t - tag the selected model
+	* d - delete the selected model
+	* r - refresh data
+	* tab - switch between panels
+	* q - quit
+
+	Starting, stopping, and model operations are executed with the dashboard suspended,
+	so their output is printed to the terminal as for the corresponding rasactl commands.
+`
+
+	uiExample = `
+	# Run the dashboard.
+	$ rasactl ui
+`
+)
+
+func uiCmd() *cobra.Command {
+
+	// cmd represents the ui command
+	cmd := &cobra.Command{
+		Use:     "ui",
+		Short:   "run a terminal dashboard for deployments",
+		Long:    templates.LongDesc(uiDesc),
+		Example: templates.Examples(uiExample),
+		Args:    cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkIfDeploymentsExist()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := rasaCtl.UI(); err != nil {
+				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func init() {
+
+	uiCmd := uiCmd()
+	rootCmd.AddCommand(uiCmd)
+}
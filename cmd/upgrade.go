@@ -34,11 +34,17 @@ The upgrade command upgrades or changes configuration for Rasa X / Enterprise de
 
 You can specify a values file with you custom configuration. The values file has the same form as a values file for helm chart.
 Here you can find all available values that can be configured: https://github.com/RasaHQ/rasa-x-helm/blob/main/charts/rasa-x/values.yaml
+
+Use the --dry-run flag to print the rendered manifest, or the --diff flag to print a diff against the current deployment.
+No changes are applied if one of the flags is used. Values of secrets are redacted in the output of both flags.
 `
 
 	upgradeExample = `
 	# Change configuration for Rasa X / Enterprise deployment by passing a custom configuration.
 	$ rasactl upgrade my-deployment --values-file my-custom-values.yaml
+
+	# Show changes that would be applied by the upgrade without applying them.
+	$ rasactl upgrade my-deployment --values-file my-custom-values.yaml --diff
 `
 )
 
@@ -88,11 +94,14 @@ func upgradeCmd() *cobra.Command {
 				return nil
 			}
 
+			defer rasaCtl.Spinner.Stop()
 			if err := rasaCtl.Upgrade(); err != nil {
 				return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 			}
-			rasaCtl.Spinner.Message("Ready!")
-			defer rasaCtl.Spinner.Stop()
+
+			if !rasactlFlags.StartUpgrade.DryRun && !rasactlFlags.StartUpgrade.Diff {
+				rasaCtl.Spinner.Message("Ready!")
+			}
 			return nil
 		},
 	}
@@ -19,6 +19,9 @@ func HandleSignals(sigs chan os.Signal) {
 }
 
 func runOnClose(signal os.Signal) {
+	if rasaCtl != nil {
+		rasaCtl.Cleanup(signal)
+	}
 	emoji.Println("Bye :wave:")
 
 	switch signal {
@@ -36,7 +39,7 @@ func checkIfNamespaceExists() error {
 		return xerrors.Errorf(errorPrint.Sprint("You have to pass a deployment name"))
 	}
 
-	isNamespaceExist, err := rasaCtl.KubernetesClient.IsNamespaceExist(rasaCtl.Namespace)
+	isNamespaceExist, err := rasaCtl.IsDeploymentExist(rasaCtl.Namespace)
 	if err != nil {
 		return xerrors.Errorf(errorPrint.Sprintf("%s", err))
 	}
@@ -48,7 +51,7 @@ func checkIfNamespaceExists() error {
 }
 
 func checkIfDeploymentsExist() error {
-	namespaces, err := rasaCtl.KubernetesClient.GetNamespaces()
+	namespaces, err := rasaCtl.GetDeployments()
 	if err != nil {
 		return xerrors.Errorf(errorPrint.Sprint(err))
 	}
@@ -66,7 +69,7 @@ func parseArgs(currentNamespace string, args []string, minArgs, maxArgs int, fla
 	nsExists := false
 	var ns string
 
-	namespaces, err := rasaCtl.KubernetesClient.GetNamespaces()
+	namespaces, err := rasaCtl.GetDeployments()
 	if err != nil {
 		return nil, xerrors.Errorf(errorPrint.Sprint(err))
 	}
@@ -96,7 +99,7 @@ func parseArgs(currentNamespace string, args []string, minArgs, maxArgs int, fla
 
 	// Check if args[0] is a namespace
 	if len(args) != 0 {
-		nsExists, err = rasaCtl.KubernetesClient.IsNamespaceExist(args[0])
+		nsExists, err = rasaCtl.IsDeploymentExist(args[0])
 		if err != nil {
 			return nil, err
 		}
@@ -12,7 +12,10 @@ require (
 	github.com/danieljoos/wincred v1.1.2 // indirect
 	github.com/docker/docker v20.10.12+incompatible
 	github.com/docker/docker-credential-helpers v0.6.4
+	github.com/docker/go-connections v0.4.0
 	github.com/fatih/color v1.13.0
+	github.com/fsnotify/fsnotify v1.5.1
+	github.com/gdamore/tcell/v2 v2.4.1-0.20210905002822-f057f0a857a1
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-logr/logr v1.2.2
 	github.com/golang/mock v1.6.0
@@ -27,6 +30,8 @@ require (
 	github.com/onsi/gomega v1.17.0
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/rivo/tview v0.0.0-20211202162923-2a6de950f73b
 	github.com/schollz/progressbar/v3 v3.8.5
 	github.com/spf13/cobra v1.3.0
 	github.com/spf13/viper v1.10.1
@@ -45,5 +50,6 @@ require (
 	k8s.io/kubectl v0.23.1
 	sigs.k8s.io/cluster-api v1.0.2
 	sigs.k8s.io/controller-runtime v0.11.0
+	sigs.k8s.io/kind v0.11.1
 	sigs.k8s.io/yaml v1.3.0
 )
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -52,6 +53,24 @@ type Interface interface {
 	SetKind(kind KindSpec)
 	SetProjectPath(path string)
 	GetKindNetworkGatewayAddress() (string, error)
+	SaveImages(images []string, w io.Writer) error
+	LoadImages(file string) error
+	CreateKindCluster(spec KindClusterSpec) error
+	DeleteKindCluster(name, kubeconfigPath string) error
+	GetKindClusterNodes(name string) ([]types.Container, error)
+	CreateComposeStack(spec ComposeSpec) error
+	RecreateComposeService(spec ComposeSpec, service string) error
+	StopComposeStack(name string) error
+	DeleteComposeStack(name string) error
+	GetComposeContainers(name string) ([]types.Container, error)
+	GetContainerLogs(container string, options types.ContainerLogsOptions) (io.ReadCloser, error)
+	ReadComposeState(name string) (*ComposeState, error)
+	SaveComposeState(state *ComposeState) error
+	DeleteComposeState(name string) error
+	GetComposeDeployments() ([]string, error)
+	RunRasaServer(spec RasaServerSpec) error
+	DeleteRasaServer(name string) error
+	RunRasaCommand(spec RasaServerSpec, output io.Writer) error
 }
 
 // Docker represents a Docker client.
@@ -274,14 +293,11 @@ func (d *Docker) joinKindNodeToKubernetesCluster(container container.ContainerCr
 	return nil
 }
 
-// CreateKindNode creates a new container that is used as a kind node.
-func (d *Docker) CreateKindNode(hostname string) (container.ContainerCreateCreatedBody, error) {
-	kindImage := fmt.Sprintf("%s%s", kindImagePrefix, d.Kind.Version)
-
-	d.Log.Info("Pulling image", "image", kindImage)
-	imagePull, err := d.Client.ImagePull(d.Ctx, kindImage, types.ImagePullOptions{})
+func (d *Docker) pullImage(image string) error {
+	d.Log.Info("Pulling image", "image", image)
+	imagePull, err := d.Client.ImagePull(d.Ctx, image, types.ImagePullOptions{})
 	if err != nil {
-		return container.ContainerCreateCreatedBody{}, err
+		return err
 	}
 
 	imagePullLogs := bufio.NewReader(imagePull)
@@ -296,6 +312,17 @@ func (d *Docker) CreateKindNode(hostname string) (container.ContainerCreateCreat
 	}
 	imagePull.Close()
 
+	return nil
+}
+
+// CreateKindNode creates a new container that is used as a kind node.
+func (d *Docker) CreateKindNode(hostname string) (container.ContainerCreateCreatedBody, error) {
+	kindImage := fmt.Sprintf("%s%s", kindImagePrefix, d.Kind.Version)
+
+	if err := d.pullImage(kindImage); err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+
 	d.Log.Info("Creating a kind node", "node", hostname, "image", kindImage)
 
 	hostConfig := &container.HostConfig{
@@ -420,14 +447,23 @@ func (d *Docker) SetProjectPath(path string) {
 
 // GetKindNetworkGatewayAddress returns a gateway address of the KinD network.
 func (d *Docker) GetKindNetworkGatewayAddress() (string, error) {
-	container, err := d.getKindControlPlaneInfo()
+	return d.getContainerNetworkGateway(d.Kind.ControlPlaneHost)
+}
+
+// getContainerNetworkGateway returns a gateway address of the network that a given container is connected to.
+func (d *Docker) getContainerNetworkGateway(name string) (string, error) {
+	container, err := d.Client.ContainerInspect(d.Ctx, name)
 	if err != nil {
 		return "", err
 	}
 
 	networkName := container.HostConfig.NetworkMode.NetworkName()
+	network, ok := container.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", xerrors.Errorf("can't find the %s network for the %s container", networkName, name)
+	}
 
-	return container.NetworkSettings.Networks[networkName].Gateway, nil
+	return network.Gateway, nil
 }
 
 func (d *Docker) getKindControlPlaneInfo() (types.ContainerJSON, error) {
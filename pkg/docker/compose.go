@@ -0,0 +1,482 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	"github.com/google/uuid"
+)
+
+const (
+	// composeDeploymentLabel is a label that stores a deployment name for Docker resources
+	// created by the Docker backend.
+	composeDeploymentLabel = "rasactl.deployment"
+
+	// composeServiceLabel is a label that stores a service name for containers
+	// created by the Docker backend.
+	composeServiceLabel = "rasactl.service"
+
+	// ComposeRasaXVersion is a Rasa X version used by the Docker backend by default.
+	ComposeRasaXVersion = "1.0.1"
+
+	// ComposeServiceRasaX is the name of the Rasa X service.
+	ComposeServiceRasaX = "rasa-x"
+
+	// ComposeServicePostgreSQL is the name of the PostgreSQL service.
+	ComposeServicePostgreSQL = "db"
+
+	// ComposeServiceRabbitMQ is the name of the RabbitMQ service.
+	ComposeServiceRabbitMQ = "rabbit"
+
+	// ComposeServiceRedis is the name of the Redis service.
+	ComposeServiceRedis = "redis"
+
+	// ComposeServiceNginx is the name of the nginx service.
+	ComposeServiceNginx = "nginx"
+
+	// ComposePostgreSQLUsername is a username used to connect to PostgreSQL.
+	ComposePostgreSQLUsername = "admin"
+
+	// ComposePostgreSQLDatabase is a name of the Rasa X database.
+	ComposePostgreSQLDatabase = "rasa"
+
+	// ComposeRabbitMQUsername is a username used to connect to RabbitMQ.
+	ComposeRabbitMQUsername = "user"
+
+	// ComposeRabbitMQQueue is a name of the queue used by the Rasa X event service.
+	ComposeRabbitMQQueue = "rasa_production_events"
+)
+
+// ComposeServices lists services of the Docker backend in the order they are started.
+var ComposeServices = []string{
+	ComposeServicePostgreSQL,
+	ComposeServiceRabbitMQ,
+	ComposeServiceRedis,
+	ComposeServiceRasaX,
+	ComposeServiceNginx,
+}
+
+// composeImages stores images for services other than Rasa X.
+var composeImages = map[string]string{
+	ComposeServicePostgreSQL: "bitnami/postgresql:12.8.0",
+	ComposeServiceRabbitMQ:   "bitnami/rabbitmq:3.8.22",
+	ComposeServiceRedis:      "bitnami/redis:6.2.5",
+	ComposeServiceNginx:      "nginx:1.21-alpine",
+}
+
+// composeNginxConfig is the nginx configuration that proxies all requests to Rasa X.
+// The upstream is resolved with the Docker embedded DNS server on each request,
+// so that nginx keeps working after the rasa-x container is recreated.
+const composeNginxConfig = `server {
+  listen 80;
+  client_max_body_size 800M;
+  resolver 127.0.0.11 valid=10s;
+  set $rasa_x http://rasa-x:5002;
+
+  location / {
+    proxy_pass $rasa_x;
+    proxy_http_version 1.1;
+    proxy_set_header Upgrade $http_upgrade;
+    proxy_set_header Connection "upgrade";
+    proxy_set_header Host $host;
+    proxy_read_timeout 3600;
+  }
+}
+`
+
+// ComposeSpec defines a Rasa X stack (rasa-x, postgres, rabbitmq, redis, nginx)
+// that runs as Docker containers.
+type ComposeSpec struct {
+	// Name is a deployment name.
+	Name string `json:"name"`
+
+	// RasaXVersion is a version of the Rasa X image.
+	RasaXVersion string `json:"rasaXVersion"`
+
+	// RasaXPassword is a password for the Rasa X admin user.
+	RasaXPassword string `json:"rasaXPassword"`
+
+	// ProjectPath is a path to a local Rasa project mounted in the Rasa X container.
+	ProjectPath string `json:"projectPath,omitempty"`
+
+	// NginxPort is a port on the local machine under which Rasa X is available.
+	NginxPort int `json:"nginxPort"`
+
+	// PostgreSQLPort is a port on the local machine under which PostgreSQL is available.
+	PostgreSQLPort int `json:"postgresqlPort"`
+
+	// RabbitMQPort is a port on the local machine under which RabbitMQ is available.
+	RabbitMQPort int `json:"rabbitmqPort"`
+
+	// RedisPort is a port on the local machine under which Redis is available,
+	// it's 0 for deployments created before Redis was published.
+	RedisPort int `json:"redisPort,omitempty"`
+
+	PostgreSQLPassword string `json:"postgresqlPassword"`
+	RabbitMQPassword   string `json:"rabbitmqPassword"`
+	RedisPassword      string `json:"redisPassword"`
+	RasaXToken         string `json:"rasaXToken"`
+	RasaToken          string `json:"rasaToken"`
+	JWTSecret          string `json:"jwtSecret"`
+	PasswordSalt       string `json:"passwordSalt"`
+
+	// RasaProductionURL and RasaWorkerURL are URLs of Rasa servers used by Rasa X.
+	RasaProductionURL string `json:"rasaProductionURL"`
+	RasaWorkerURL     string `json:"rasaWorkerURL"`
+}
+
+// NewComposeSpec returns a specification for a new deployment with generated secrets
+// and free ports on the local machine.
+func NewComposeSpec(name, rasaXVersion, rasaXPassword, projectPath string) (ComposeSpec, error) {
+	spec := ComposeSpec{
+		Name:               name,
+		RasaXVersion:       rasaXVersion,
+		RasaXPassword:      rasaXPassword,
+		ProjectPath:        projectPath,
+		PostgreSQLPassword: composeSecret(),
+		RabbitMQPassword:   composeSecret(),
+		RedisPassword:      composeSecret(),
+		RasaXToken:         composeSecret(),
+		RasaToken:          composeSecret(),
+		JWTSecret:          composeSecret(),
+		PasswordSalt:       composeSecret(),
+		RasaProductionURL:  "http://rasa-production:5005",
+		RasaWorkerURL:      "http://rasa-worker:5005",
+	}
+
+	for _, port := range []*int{&spec.NginxPort, &spec.PostgreSQLPort, &spec.RabbitMQPort, &spec.RedisPort} {
+		p, err := freePort()
+		if err != nil {
+			return spec, err
+		}
+		*port = p
+	}
+
+	return spec, nil
+}
+
+// RasaXURL returns a URL under which Rasa X is available on the local machine.
+func (s ComposeSpec) RasaXURL() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", s.NginxPort)
+}
+
+// CreateComposeStack creates and starts containers for all services of a given deployment.
+// Containers that already exist are started.
+func (d *Docker) CreateComposeStack(spec ComposeSpec) error {
+	networkName := composeResourceName(spec.Name, "")
+	if _, err := d.Client.NetworkInspect(d.Ctx, networkName, types.NetworkInspectOptions{}); client.IsErrNotFound(err) {
+		d.Log.Info("Creating a network", "network", networkName)
+		if _, err := d.Client.NetworkCreate(d.Ctx, networkName, types.NetworkCreate{
+			CheckDuplicate: true,
+			Labels:         map[string]string{composeDeploymentLabel: spec.Name},
+		}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for _, service := range ComposeServices {
+		if err := d.createComposeService(spec, service); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecreateComposeService removes a container for a given service and creates it again,
+// it's used to apply a changed specification.
+func (d *Docker) RecreateComposeService(spec ComposeSpec, service string) error {
+	name := composeResourceName(spec.Name, service)
+	d.Log.Info("Removing a container", "container", name)
+	if err := d.Client.ContainerRemove(d.Ctx, name, types.ContainerRemoveOptions{Force: true}); err != nil && !client.IsErrNotFound(err) {
+		return err
+	}
+
+	return d.createComposeService(spec, service)
+}
+
+func (d *Docker) createComposeService(spec ComposeSpec, service string) error {
+	name := composeResourceName(spec.Name, service)
+
+	if _, err := d.Client.ContainerInspect(d.Ctx, name); err == nil {
+		d.Log.Info("Starting an existing container", "container", name)
+		return d.Client.ContainerStart(d.Ctx, name, types.ContainerStartOptions{})
+	} else if !client.IsErrNotFound(err) {
+		return err
+	}
+
+	config, hostConfig := composeContainerConfig(spec, service)
+
+	d.Spinner.Message(fmt.Sprintf("Pulling the %s image", config.Image))
+	if err := d.pullImage(config.Image); err != nil {
+		return err
+	}
+
+	d.Spinner.Message(fmt.Sprintf("Creating the %s service", service))
+	d.Log.Info("Creating a container", "container", name, "image", config.Image)
+	resp, err := d.Client.ContainerCreate(d.Ctx, config, hostConfig,
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				composeResourceName(spec.Name, ""): {Aliases: []string{service}},
+			},
+		},
+		nil,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+
+	return d.Client.ContainerStart(d.Ctx, resp.ID, types.ContainerStartOptions{})
+}
+
+// StopComposeStack stops containers of a given deployment.
+func (d *Docker) StopComposeStack(name string) error {
+	timeout := time.Minute * 1
+	for i := len(ComposeServices) - 1; i >= 0; i-- {
+		container := composeResourceName(name, ComposeServices[i])
+		d.Log.Info("Stopping a container", "container", container)
+		if err := d.Client.ContainerStop(d.Ctx, container, &timeout); err != nil && !client.IsErrNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteComposeStack deletes containers, volumes and the network of a given deployment.
+func (d *Docker) DeleteComposeStack(name string) error {
+	containers, err := d.GetComposeContainers(name)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range containers {
+		d.Log.Info("Removing a container", "container", c.ID)
+		if err := d.Client.ContainerRemove(d.Ctx, c.ID, types.ContainerRemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			return err
+		}
+	}
+
+	labelFilter := filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", composeDeploymentLabel, name)))
+	volumes, err := d.Client.VolumeList(d.Ctx, labelFilter)
+	if err != nil {
+		return err
+	}
+	for _, v := range volumes.Volumes {
+		d.Log.Info("Removing a volume", "volume", v.Name)
+		if err := d.Client.VolumeRemove(d.Ctx, v.Name, true); err != nil {
+			return err
+		}
+	}
+
+	networkName := composeResourceName(name, "")
+	d.Log.Info("Removing a network", "network", networkName)
+	if err := d.Client.NetworkRemove(d.Ctx, networkName); err != nil && !client.IsErrNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// GetComposeContainers returns containers of a given deployment.
+func (d *Docker) GetComposeContainers(name string) ([]types.Container, error) {
+	return d.Client.ContainerList(d.Ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", composeDeploymentLabel, name))),
+	})
+}
+
+// GetContainerLogs returns a stream with logs of a given container.
+// The stdout and stderr streams are merged into one stream.
+func (d *Docker) GetContainerLogs(container string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	options.ShowStdout = true
+	options.ShowStderr = true
+
+	logs, err := d.Client.ContainerLogs(d.Ctx, container, options)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		logs.Close()
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+// composeContainerConfig returns configuration for a container that runs a given service.
+func composeContainerConfig(spec ComposeSpec, service string) (*container.Config, *container.HostConfig) {
+	labels := map[string]string{
+		composeDeploymentLabel: spec.Name,
+		composeServiceLabel:    service,
+	}
+	config := &container.Config{
+		Image:    composeImages[service],
+		Hostname: service,
+		Labels:   labels,
+	}
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+	}
+
+	switch service {
+	case ComposeServicePostgreSQL:
+		config.Env = []string{
+			fmt.Sprintf("POSTGRESQL_USERNAME=%s", ComposePostgreSQLUsername),
+			fmt.Sprintf("POSTGRESQL_PASSWORD=%s", spec.PostgreSQLPassword),
+			fmt.Sprintf("POSTGRESQL_DATABASE=%s", ComposePostgreSQLDatabase),
+		}
+		hostConfig.Mounts = []mount.Mount{composeVolume(spec.Name, service, "/bitnami/postgresql")}
+		publishPort(config, hostConfig, "5432/tcp", spec.PostgreSQLPort)
+
+	case ComposeServiceRabbitMQ:
+		config.Env = []string{
+			fmt.Sprintf("RABBITMQ_USERNAME=%s", ComposeRabbitMQUsername),
+			fmt.Sprintf("RABBITMQ_PASSWORD=%s", spec.RabbitMQPassword),
+			"RABBITMQ_DISK_FREE_LIMIT={mem_relative, 0.1}",
+		}
+		publishPort(config, hostConfig, "5672/tcp", spec.RabbitMQPort)
+
+	case ComposeServiceRedis:
+		config.Env = []string{
+			fmt.Sprintf("REDIS_PASSWORD=%s", spec.RedisPassword),
+		}
+		if spec.RedisPort != 0 {
+			publishPort(config, hostConfig, "6379/tcp", spec.RedisPort)
+		}
+
+	case ComposeServiceRasaX:
+		config.Image = fmt.Sprintf("rasa/rasa-x:%s", spec.RasaXVersion)
+		config.Env = []string{
+			"SELF_PORT=5002",
+			"RASA_X_USERNAME=me",
+			fmt.Sprintf("RASA_X_PASSWORD=%s", spec.RasaXPassword),
+			fmt.Sprintf("PASSWORD_SALT=%s", spec.PasswordSalt),
+			fmt.Sprintf("RASA_X_TOKEN=%s", spec.RasaXToken),
+			fmt.Sprintf("JWT_SECRET=%s", spec.JWTSecret),
+			fmt.Sprintf("RASA_TOKEN=%s", spec.RasaToken),
+			fmt.Sprintf("RASA_PRODUCTION_HOST=%s", spec.RasaProductionURL),
+			fmt.Sprintf("RASA_WORKER_HOST=%s", spec.RasaWorkerURL),
+			"RASA_USER_APP=http://app:5055",
+			"RUN_DATABASE_MIGRATION_AS_SEPARATE_SERVICE=false",
+			"RASA_MODEL_DIR=/app/models",
+			"RASA_X_USER_ANALYTICS=0",
+			"SANIC_RESPONSE_TIMEOUT=3600",
+			"METRICS_CONSENT=false",
+			"LOCAL_MODE=false",
+			"DB_DRIVER=postgresql",
+			fmt.Sprintf("DB_HOST=%s", ComposeServicePostgreSQL),
+			"DB_PORT=5432",
+			fmt.Sprintf("DB_USER=%s", ComposePostgreSQLUsername),
+			fmt.Sprintf("DB_PASSWORD=%s", spec.PostgreSQLPassword),
+			fmt.Sprintf("DB_DATABASE=%s", ComposePostgreSQLDatabase),
+			fmt.Sprintf("RABBITMQ_HOST=%s", ComposeServiceRabbitMQ),
+			"RABBITMQ_PORT=5672",
+			fmt.Sprintf("RABBITMQ_USERNAME=%s", ComposeRabbitMQUsername),
+			fmt.Sprintf("RABBITMQ_PASSWORD=%s", spec.RabbitMQPassword),
+			fmt.Sprintf("RABBITMQ_QUEUE=%s", ComposeRabbitMQQueue),
+			fmt.Sprintf("REDIS_HOST=%s", ComposeServiceRedis),
+			"REDIS_PORT=6379",
+			fmt.Sprintf("REDIS_PASSWORD=%s", spec.RedisPassword),
+			"REDIS_DB=1",
+		}
+		// host.docker.internal is used to connect Rasa X with a Rasa server running on the local machine.
+		hostConfig.ExtraHosts = []string{"host.docker.internal:host-gateway"}
+		hostConfig.Mounts = []mount.Mount{composeVolume(spec.Name, service, "/app/models")}
+		if spec.ProjectPath != "" {
+			hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+				Source: spec.ProjectPath,
+				Target: "/app/local_project",
+				Type:   mount.TypeBind,
+			})
+		}
+
+	case ComposeServiceNginx:
+		config.Env = []string{fmt.Sprintf("NGINX_CONFIG=%s", composeNginxConfig)}
+		config.Cmd = []string{"sh", "-c",
+			`printf '%s' "$NGINX_CONFIG" > /etc/nginx/conf.d/default.conf && exec nginx -g 'daemon off;'`}
+		publishPort(config, hostConfig, "80/tcp", spec.NginxPort)
+	}
+
+	return config, hostConfig
+}
+
+// publishPort publishes a container port on a given port of the local machine.
+func publishPort(config *container.Config, hostConfig *container.HostConfig, containerPort nat.Port, hostPort int) {
+	config.ExposedPorts = nat.PortSet{containerPort: struct{}{}}
+	hostConfig.PortBindings = nat.PortMap{
+		containerPort: []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: fmt.Sprintf("%d", hostPort)}},
+	}
+}
+
+// composeVolume returns a named volume for a given service.
+func composeVolume(name, service, target string) mount.Mount {
+	return mount.Mount{
+		Type:   mount.TypeVolume,
+		Source: composeResourceName(name, service),
+		Target: target,
+		VolumeOptions: &mount.VolumeOptions{
+			Labels: map[string]string{composeDeploymentLabel: name},
+		},
+	}
+}
+
+// composeResourceName returns a name of a Docker resource for a given deployment and service.
+// The network name is returned if the service is empty.
+func composeResourceName(name, service string) string {
+	if service == "" {
+		return fmt.Sprintf("rasactl-%s", name)
+	}
+	return fmt.Sprintf("rasactl-%s-%s", name, service)
+}
+
+// ComposeContainerService returns a service name of a given container.
+func ComposeContainerService(c types.Container) string {
+	return c.Labels[composeServiceLabel]
+}
+
+func composeSecret() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// freePort returns a free TCP port on the local machine.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// ComposeState stores the state of a deployment that uses the Docker backend.
+// It replaces the rasactl secret used by the Kubernetes backend.
+type ComposeState struct {
+	// Spec stores the specification of the deployment.
+	Spec ComposeSpec `json:"spec"`
+
+	// Data stores the same keys as the rasactl secret, e.g. types.StateProjectPath.
+	Data map[string]string `json:"data"`
+}
+
+// StateData returns the state data in the same format as the rasactl secret.
+func (s *ComposeState) StateData() map[string][]byte {
+	data := map[string][]byte{}
+	for key, value := range s.Data {
+		data[key] = []byte(value)
+	}
+	return data
+}
+
+// ReadComposeState reads the state of a given deployment.
+func (d *Docker) ReadComposeState(name string) (*ComposeState, error) {
+	file, err := composeStateFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ComposeState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Data == nil {
+		state.Data = map[string]string{}
+	}
+
+	return state, nil
+}
+
+// SaveComposeState saves the state of a deployment.
+func (d *Docker) SaveComposeState(state *ComposeState) error {
+	file, err := composeStateFile(state.Spec.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	d.Log.V(1).Info("Saving deployment state", "file", file)
+	// The state contains passwords, the file is readable only for the current user.
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+// DeleteComposeState deletes the state of a given deployment.
+func (d *Docker) DeleteComposeState(name string) error {
+	file, err := composeStateFile(name)
+	if err != nil {
+		return err
+	}
+
+	d.Log.V(1).Info("Deleting deployment state", "file", file)
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetComposeDeployments returns names of deployments that use the Docker backend.
+func (d *Docker) GetComposeDeployments() ([]string, error) {
+	dir, err := composeStateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	deployments := []string{}
+	for _, f := range files {
+		if !f.IsDir() && filepath.Ext(f.Name()) == ".json" {
+			deployments = append(deployments, strings.TrimSuffix(f.Name(), ".json"))
+		}
+	}
+	sort.Strings(deployments)
+
+	return deployments, nil
+}
+
+// composeStateDir returns a directory that stores state files, $HOME/.rasactl/docker.
+func composeStateDir() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".rasactl", "docker"), nil
+}
+
+func composeStateFile(name string) (string, error) {
+	dir, err := composeStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
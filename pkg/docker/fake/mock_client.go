@@ -5,8 +5,10 @@
 package fake
 
 import (
+	io "io"
 	reflect "reflect"
 
+	types "github.com/docker/docker/api/types"
 	container "github.com/docker/docker/api/types/container"
 	gomock "github.com/golang/mock/gomock"
 
@@ -36,6 +38,34 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// CreateComposeStack mocks base method.
+func (m *MockInterface) CreateComposeStack(arg0 docker.ComposeSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComposeStack", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComposeStack indicates an expected call of CreateComposeStack.
+func (mr *MockInterfaceMockRecorder) CreateComposeStack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComposeStack", reflect.TypeOf((*MockInterface)(nil).CreateComposeStack), arg0)
+}
+
+// CreateKindCluster mocks base method.
+func (m *MockInterface) CreateKindCluster(arg0 docker.KindClusterSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKindCluster", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateKindCluster indicates an expected call of CreateKindCluster.
+func (mr *MockInterfaceMockRecorder) CreateKindCluster(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKindCluster", reflect.TypeOf((*MockInterface)(nil).CreateKindCluster), arg0)
+}
+
 // CreateKindNode mocks base method.
 func (m *MockInterface) CreateKindNode(arg0 string) (container.ContainerCreateCreatedBody, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +81,48 @@ func (mr *MockInterfaceMockRecorder) CreateKindNode(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKindNode", reflect.TypeOf((*MockInterface)(nil).CreateKindNode), arg0)
 }
 
+// DeleteComposeStack mocks base method.
+func (m *MockInterface) DeleteComposeStack(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComposeStack", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComposeStack indicates an expected call of DeleteComposeStack.
+func (mr *MockInterfaceMockRecorder) DeleteComposeStack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComposeStack", reflect.TypeOf((*MockInterface)(nil).DeleteComposeStack), arg0)
+}
+
+// DeleteComposeState mocks base method.
+func (m *MockInterface) DeleteComposeState(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComposeState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComposeState indicates an expected call of DeleteComposeState.
+func (mr *MockInterfaceMockRecorder) DeleteComposeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComposeState", reflect.TypeOf((*MockInterface)(nil).DeleteComposeState), arg0)
+}
+
+// DeleteKindCluster mocks base method.
+func (m *MockInterface) DeleteKindCluster(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteKindCluster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteKindCluster indicates an expected call of DeleteKindCluster.
+func (mr *MockInterfaceMockRecorder) DeleteKindCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKindCluster", reflect.TypeOf((*MockInterface)(nil).DeleteKindCluster), arg0, arg1)
+}
+
 // DeleteKindNode mocks base method.
 func (m *MockInterface) DeleteKindNode(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -65,6 +137,65 @@ func (mr *MockInterfaceMockRecorder) DeleteKindNode(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKindNode", reflect.TypeOf((*MockInterface)(nil).DeleteKindNode), arg0)
 }
 
+// DeleteRasaServer mocks base method.
+func (m *MockInterface) DeleteRasaServer(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRasaServer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRasaServer indicates an expected call of DeleteRasaServer.
+func (mr *MockInterfaceMockRecorder) DeleteRasaServer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRasaServer", reflect.TypeOf((*MockInterface)(nil).DeleteRasaServer), arg0)
+}
+
+// GetComposeContainers mocks base method.
+func (m *MockInterface) GetComposeContainers(arg0 string) ([]types.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComposeContainers", arg0)
+	ret0, _ := ret[0].([]types.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComposeContainers indicates an expected call of GetComposeContainers.
+func (mr *MockInterfaceMockRecorder) GetComposeContainers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComposeContainers", reflect.TypeOf((*MockInterface)(nil).GetComposeContainers), arg0)
+}
+
+// GetComposeDeployments mocks base method.
+func (m *MockInterface) GetComposeDeployments() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComposeDeployments")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComposeDeployments indicates an expected call of GetComposeDeployments.
+func (mr *MockInterfaceMockRecorder) GetComposeDeployments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComposeDeployments", reflect.TypeOf((*MockInterface)(nil).GetComposeDeployments))
+}
+
+// GetContainerLogs mocks base method.
+func (m *MockInterface) GetContainerLogs(arg0 string, arg1 types.ContainerLogsOptions) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContainerLogs", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContainerLogs indicates an expected call of GetContainerLogs.
+func (mr *MockInterfaceMockRecorder) GetContainerLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContainerLogs", reflect.TypeOf((*MockInterface)(nil).GetContainerLogs), arg0, arg1)
+}
+
 // GetKind mocks base method.
 func (m *MockInterface) GetKind() docker.KindSpec {
 	m.ctrl.T.Helper()
@@ -79,6 +210,21 @@ func (mr *MockInterfaceMockRecorder) GetKind() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKind", reflect.TypeOf((*MockInterface)(nil).GetKind))
 }
 
+// GetKindClusterNodes mocks base method.
+func (m *MockInterface) GetKindClusterNodes(arg0 string) ([]types.Container, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKindClusterNodes", arg0)
+	ret0, _ := ret[0].([]types.Container)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKindClusterNodes indicates an expected call of GetKindClusterNodes.
+func (mr *MockInterfaceMockRecorder) GetKindClusterNodes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKindClusterNodes", reflect.TypeOf((*MockInterface)(nil).GetKindClusterNodes), arg0)
+}
+
 // GetKindNetworkGatewayAddress mocks base method.
 func (m *MockInterface) GetKindNetworkGatewayAddress() (string, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +240,105 @@ func (mr *MockInterfaceMockRecorder) GetKindNetworkGatewayAddress() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKindNetworkGatewayAddress", reflect.TypeOf((*MockInterface)(nil).GetKindNetworkGatewayAddress))
 }
 
+// LoadImages mocks base method.
+func (m *MockInterface) LoadImages(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadImages", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadImages indicates an expected call of LoadImages.
+func (mr *MockInterfaceMockRecorder) LoadImages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadImages", reflect.TypeOf((*MockInterface)(nil).LoadImages), arg0)
+}
+
+// ReadComposeState mocks base method.
+func (m *MockInterface) ReadComposeState(arg0 string) (*docker.ComposeState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadComposeState", arg0)
+	ret0, _ := ret[0].(*docker.ComposeState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadComposeState indicates an expected call of ReadComposeState.
+func (mr *MockInterfaceMockRecorder) ReadComposeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadComposeState", reflect.TypeOf((*MockInterface)(nil).ReadComposeState), arg0)
+}
+
+// RecreateComposeService mocks base method.
+func (m *MockInterface) RecreateComposeService(arg0 docker.ComposeSpec, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecreateComposeService", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecreateComposeService indicates an expected call of RecreateComposeService.
+func (mr *MockInterfaceMockRecorder) RecreateComposeService(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecreateComposeService", reflect.TypeOf((*MockInterface)(nil).RecreateComposeService), arg0, arg1)
+}
+
+// RunRasaCommand mocks base method.
+func (m *MockInterface) RunRasaCommand(arg0 docker.RasaServerSpec, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRasaCommand", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunRasaCommand indicates an expected call of RunRasaCommand.
+func (mr *MockInterfaceMockRecorder) RunRasaCommand(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRasaCommand", reflect.TypeOf((*MockInterface)(nil).RunRasaCommand), arg0, arg1)
+}
+
+// RunRasaServer mocks base method.
+func (m *MockInterface) RunRasaServer(arg0 docker.RasaServerSpec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunRasaServer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunRasaServer indicates an expected call of RunRasaServer.
+func (mr *MockInterfaceMockRecorder) RunRasaServer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunRasaServer", reflect.TypeOf((*MockInterface)(nil).RunRasaServer), arg0)
+}
+
+// SaveComposeState mocks base method.
+func (m *MockInterface) SaveComposeState(arg0 *docker.ComposeState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveComposeState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveComposeState indicates an expected call of SaveComposeState.
+func (mr *MockInterfaceMockRecorder) SaveComposeState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveComposeState", reflect.TypeOf((*MockInterface)(nil).SaveComposeState), arg0)
+}
+
+// SaveImages mocks base method.
+func (m *MockInterface) SaveImages(arg0 []string, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveImages indicates an expected call of SaveImages.
+func (mr *MockInterfaceMockRecorder) SaveImages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImages", reflect.TypeOf((*MockInterface)(nil).SaveImages), arg0, arg1)
+}
+
 // SetKind mocks base method.
 func (m *MockInterface) SetKind(arg0 docker.KindSpec) {
 	m.ctrl.T.Helper()
@@ -144,6 +389,20 @@ func (mr *MockInterfaceMockRecorder) StartKindNode(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartKindNode", reflect.TypeOf((*MockInterface)(nil).StartKindNode), arg0)
 }
 
+// StopComposeStack mocks base method.
+func (m *MockInterface) StopComposeStack(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopComposeStack", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopComposeStack indicates an expected call of StopComposeStack.
+func (mr *MockInterfaceMockRecorder) StopComposeStack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopComposeStack", reflect.TypeOf((*MockInterface)(nil).StopComposeStack), arg0)
+}
+
 // StopKindNode mocks base method.
 func (m *MockInterface) StopKindNode(arg0 string) error {
 	m.ctrl.T.Helper()
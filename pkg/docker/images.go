@@ -0,0 +1,139 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/xerrors"
+)
+
+// SaveImages pulls given images and writes them to w in the docker save format.
+func (d *Docker) SaveImages(images []string, w io.Writer) error {
+	for _, image := range images {
+		d.Spinner.Message(fmt.Sprintf("Pulling the %s image", image))
+		if err := d.pullImage(image); err != nil {
+			return err
+		}
+	}
+
+	d.Log.Info("Saving images", "images", images)
+	reader, err := d.Client.ImageSave(d.Ctx, images)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
+
+// LoadImages loads images from a file in the docker save format into all nodes of the kind cluster.
+func (d *Docker) LoadImages(file string) error {
+	nodes, err := d.getKindNodes()
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if err := d.loadImagesToNode(node, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Docker) getKindNodes() ([]string, error) {
+	controlPlane, err := d.getKindControlPlaneInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := controlPlane.Config.Labels["io.x-k8s.kind.cluster"]
+	containers, err := d.Client.ContainerList(d.Ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("io.x-k8s.kind.cluster=%s", cluster))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []string{}
+	for _, c := range containers {
+		if len(c.Names) != 0 {
+			nodes = append(nodes, strings.TrimPrefix(c.Names[0], "/"))
+		}
+	}
+
+	return nodes, nil
+}
+
+func (d *Docker) loadImagesToNode(node, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	d.Log.Info("Loading images into a node", "node", node, "file", file)
+
+	execSpec, err := d.Client.ContainerExecCreate(d.Ctx, node, types.ExecConfig{
+		WorkingDir:   "/",
+		Cmd:          []string{"ctr", "--namespace=k8s.io", "images", "import", "--digests", "-"},
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.Client.ContainerExecAttach(d.Ctx, execSpec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	if _, err := io.Copy(resp.Conn, f); err != nil {
+		return err
+	}
+	if err := resp.CloseWrite(); err != nil {
+		return err
+	}
+
+	output := new(bytes.Buffer)
+	if _, err := output.ReadFrom(resp.Reader); err != nil {
+		return err
+	}
+	d.Log.V(1).Info("Loading images into a node", "node", node, "details", output.String())
+
+	status, err := d.Client.ContainerExecInspect(d.Ctx, execSpec.ID)
+	if err != nil {
+		return err
+	}
+
+	if status.ExitCode != 0 {
+		return xerrors.Errorf("can't load images into the %s node: %s", node, output.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+	"golang.org/x/xerrors"
+
+	rtypes "github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	// k3dClusterLabel is a label that k3d sets on containers that belong to a cluster.
+	k3dClusterLabel = "k3d.cluster"
+
+	// k3dRoleLabel is a label that k3d sets on containers to define a node role.
+	k3dRoleLabel = "k3d.role"
+
+	// k3dTokenLabel is a label that k3d sets on node containers to store the cluster token.
+	k3dTokenLabel = "k3d.cluster.token"
+
+	// k3dNodeReadyTimeout defines how long to wait for a k3d node to be ready.
+	k3dNodeReadyTimeout = time.Minute * 3
+)
+
+// k3dCluster is a backend for a k3d cluster. A project directory is mounted
+// into a dedicated k3s agent node that joins the cluster.
+type k3dCluster struct {
+	docker *Docker
+	spec   LocalClusterSpec
+}
+
+func (k *k3dCluster) GetType() rtypes.LocalClusterType {
+	return rtypes.LocalClusterK3d
+}
+
+func (k *k3dCluster) GetNodeName() string {
+	return fmt.Sprintf("k3d-%s", k.docker.Namespace)
+}
+
+// CreateNode creates a container with a k3s agent that uses the same image as the server node.
+func (k *k3dCluster) CreateNode() error {
+	d := k.docker
+	server, err := d.Client.ContainerInspect(d.Ctx, k.spec.ControlPlaneHost)
+	if err != nil {
+		return err
+	}
+
+	token := k3dClusterToken(server)
+	if token == "" {
+		return xerrors.Errorf("can't find a cluster token for the %s k3d node", k.spec.ControlPlaneHost)
+	}
+
+	nodeName := k.GetNodeName()
+	d.Log.Info("Creating a k3d node", "node", nodeName, "image", server.Config.Image)
+
+	hostConfig := &container.HostConfig{
+		Privileged: true,
+		Tmpfs:      map[string]string{"/run": "", "/var/run": ""},
+		RestartPolicy: container.RestartPolicy{
+			Name:              "on-failure",
+			MaximumRetryCount: 1,
+		},
+	}
+
+	// Mount a local directory if a project path is defined.
+	if d.ProjectPath != "" {
+		hostConfig.Mounts = []mount.Mount{
+			{
+				Source: d.ProjectPath,
+				Target: d.ProjectPath,
+				Type:   mount.TypeBind,
+			},
+		}
+	}
+
+	resp, err := d.Client.ContainerCreate(d.Ctx,
+		&container.Config{
+			Image:    server.Config.Image,
+			Hostname: nodeName,
+			Cmd: []string{
+				"agent",
+				"--node-name", nodeName,
+				"--node-label", fmt.Sprintf("rasactl-project=%s", d.Namespace),
+				"--node-taint", "rasactl=true:NoSchedule",
+			},
+			Env: []string{
+				fmt.Sprintf("K3S_URL=https://%s:6443", k.spec.ControlPlaneHost),
+				fmt.Sprintf("K3S_TOKEN=%s", token),
+			},
+			Labels: map[string]string{
+				"app":           "k3d",
+				k3dClusterLabel: server.Config.Labels[k3dClusterLabel],
+				k3dRoleLabel:    "agent",
+			},
+		},
+		hostConfig, &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				server.HostConfig.NetworkMode.NetworkName(): {},
+			},
+		},
+		nil,
+		nodeName,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Client.ContainerStart(d.Ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		return err
+	}
+
+	return k.waitForNode(nodeName)
+}
+
+// waitForNode waits until a given node is registered in the cluster and ready.
+func (k *k3dCluster) waitForNode(nodeName string) error {
+	d := k.docker
+	d.Spinner.Message("Waiting for k3d node to join to the cluster")
+
+	deadline := time.Now().Add(k3dNodeReadyTimeout)
+	for time.Now().Before(deadline) {
+		output, exitCode, err := d.execInContainer(k.spec.ControlPlaneHost, []string{
+			"kubectl", "get", "node", nodeName,
+			"-o", `jsonpath={.status.conditions[?(@.type=="Ready")].status}`,
+		})
+		if err != nil {
+			return err
+		}
+		d.Log.Info("Waiting for k3d node to join to the cluster", "node", nodeName, "exitCode", exitCode, "ready", output)
+		if exitCode == 0 && strings.TrimSpace(output) == "True" {
+			return nil
+		}
+		time.Sleep(time.Second * 2)
+	}
+
+	return xerrors.Errorf("Can't join k3d node to the cluster, the node %s is not ready after %s", nodeName, k3dNodeReadyTimeout)
+}
+
+func (k *k3dCluster) StartNode() error {
+	return k.docker.Client.ContainerStart(k.docker.Ctx, k.GetNodeName(), types.ContainerStartOptions{})
+}
+
+func (k *k3dCluster) StopNode() error {
+	timeout := time.Minute * 1
+	return k.docker.Client.ContainerStop(k.docker.Ctx, k.GetNodeName(), &timeout)
+}
+
+func (k *k3dCluster) DeleteNode() error {
+	return k.docker.Client.ContainerRemove(k.docker.Ctx, k.GetNodeName(), types.ContainerRemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+}
+
+func (k *k3dCluster) GetNetworkGatewayAddress() (string, error) {
+	return k.docker.getContainerNetworkGateway(k.spec.ControlPlaneHost)
+}
+
+func (k *k3dCluster) GetNodePortHost() (string, error) {
+	return "127.0.0.1", nil
+}
+
+// LoadImages loads images into all server and agent nodes of the k3d cluster.
+func (k *k3dCluster) LoadImages(file string) error {
+	d := k.docker
+	server, err := d.Client.ContainerInspect(d.Ctx, k.spec.ControlPlaneHost)
+	if err != nil {
+		return err
+	}
+
+	containers, err := d.Client.ContainerList(d.Ctx, types.ContainerListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", fmt.Sprintf("%s=%s", k3dClusterLabel, server.Config.Labels[k3dClusterLabel])),
+		),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range containers {
+		role := c.Labels[k3dRoleLabel]
+		if (role != "server" && role != "agent") || len(c.Names) == 0 {
+			continue
+		}
+		if err := d.loadImagesToNode(strings.TrimPrefix(c.Names[0], "/"), file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// k3dClusterToken returns a token that is used to join nodes to a k3d cluster.
+func k3dClusterToken(server types.ContainerJSON) string {
+	if token := server.Config.Labels[k3dTokenLabel]; token != "" {
+		return token
+	}
+
+	for _, env := range server.Config.Env {
+		if strings.HasPrefix(env, "K3S_TOKEN=") {
+			return strings.TrimPrefix(env, "K3S_TOKEN=")
+		}
+	}
+
+	return ""
+}
+
+// execInContainer executes a command in a given container and returns its output and exit code.
+func (d *Docker) execInContainer(name string, cmd []string) (string, int, error) {
+	execSpec, err := d.Client.ContainerExecCreate(d.Ctx, name, types.ExecConfig{
+		WorkingDir:   "/",
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	resp, err := d.Client.ContainerExecAttach(d.Ctx, execSpec.ID, types.ExecStartCheck{})
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Close()
+
+	output := new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(output, output, resp.Reader); err != nil {
+		return "", 0, err
+	}
+
+	status, err := d.Client.ContainerExecInspect(d.Ctx, execSpec.ID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return output.String(), status.ExitCode, nil
+}
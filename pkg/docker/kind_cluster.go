@@ -0,0 +1,177 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/cluster"
+	kindlog "sigs.k8s.io/kind/pkg/log"
+)
+
+const (
+	// kindClusterLabel is a label that kind sets on containers that belong to a cluster.
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+
+	// KindNodePortRangeStart is the first node port that is mapped to the host.
+	KindNodePortRangeStart = 30000
+
+	// KindNodePortRangeEnd is the last node port that is mapped to the host.
+	KindNodePortRangeEnd = 30100
+)
+
+// KindClusterSpec stores configuration for a kind cluster managed by rasactl.
+type KindClusterSpec struct {
+	// Name is a name of the kind cluster.
+	Name string
+
+	// Image is a kind node image, the default kind image is used if empty.
+	Image string
+
+	// KubeconfigPath is a path to the kubeconfig file that is updated with the cluster context.
+	KubeconfigPath string
+
+	// WaitForReady defines how long to wait for the control plane to be ready.
+	WaitForReady time.Duration
+}
+
+// CreateKindCluster creates a new kind cluster with a control plane node that has ports
+// for ingress (80, 443) and node ports mapped to the host.
+func (d *Docker) CreateKindCluster(spec KindClusterSpec) error {
+	d.Log.Info("Creating a kind cluster", "name", spec.Name, "image", spec.Image)
+
+	options := []cluster.CreateOption{
+		cluster.CreateWithV1Alpha4Config(kindClusterConfig()),
+		cluster.CreateWithKubeconfigPath(spec.KubeconfigPath),
+		cluster.CreateWithWaitForReady(spec.WaitForReady),
+		cluster.CreateWithDisplayUsage(false),
+		cluster.CreateWithDisplaySalutation(false),
+	}
+
+	if spec.Image != "" {
+		options = append(options, cluster.CreateWithNodeImage(spec.Image))
+	}
+
+	return d.kindProvider().Create(spec.Name, options...)
+}
+
+// DeleteKindCluster deletes a kind cluster along with all nodes, including nodes created by rasactl,
+// and removes the cluster context from the kubeconfig file.
+func (d *Docker) DeleteKindCluster(name, kubeconfigPath string) error {
+	d.Log.Info("Deleting a kind cluster", "name", name)
+	return d.kindProvider().Delete(name, kubeconfigPath)
+}
+
+// GetKindClusterNodes returns containers that are used as nodes of a given kind cluster.
+func (d *Docker) GetKindClusterNodes(name string) ([]types.Container, error) {
+	return d.Client.ContainerList(d.Ctx, types.ContainerListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", kindClusterLabel, name))),
+	})
+}
+
+func (d *Docker) kindProvider() *cluster.Provider {
+	return cluster.NewProvider(
+		cluster.ProviderWithDocker(),
+		cluster.ProviderWithLogger(&kindLogger{log: d.Log}),
+	)
+}
+
+// kindClusterConfig returns configuration for a kind cluster. It's the same configuration
+// as the one generated by the kind/generate-config.sh script.
+func kindClusterConfig() *v1alpha4.Cluster {
+	portMappings := []v1alpha4.PortMapping{
+		{ContainerPort: 80, HostPort: 80, Protocol: v1alpha4.PortMappingProtocolTCP},
+		{ContainerPort: 443, HostPort: 443, Protocol: v1alpha4.PortMappingProtocolTCP},
+	}
+
+	for port := int32(KindNodePortRangeStart); port <= KindNodePortRangeEnd; port++ {
+		portMappings = append(portMappings, v1alpha4.PortMapping{
+			ContainerPort: port,
+			HostPort:      port,
+			Protocol:      v1alpha4.PortMappingProtocolTCP,
+		})
+	}
+
+	return &v1alpha4.Cluster{
+		TypeMeta: v1alpha4.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: "kind.x-k8s.io/v1alpha4",
+		},
+		Nodes: []v1alpha4.Node{
+			{
+				Role: v1alpha4.ControlPlaneRole,
+				KubeadmConfigPatches: []string{
+					`kind: InitConfiguration
+nodeRegistration:
+  kubeletExtraArgs:
+    node-labels: "ingress-ready=true"`,
+					fmt.Sprintf(`kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    service-node-port-range: "%d-%d"`, KindNodePortRangeStart, KindNodePortRangeEnd),
+				},
+				ExtraPortMappings: portMappings,
+			},
+		},
+	}
+}
+
+// kindLogger passes kind logs to the rasactl logger.
+type kindLogger struct {
+	log logr.Logger
+}
+
+func (l *kindLogger) Warn(message string) {
+	l.log.Info(message)
+}
+
+func (l *kindLogger) Warnf(format string, args ...interface{}) {
+	l.log.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *kindLogger) Error(message string) {
+	l.log.Error(nil, message)
+}
+
+func (l *kindLogger) Errorf(format string, args ...interface{}) {
+	l.log.Error(nil, fmt.Sprintf(format, args...))
+}
+
+func (l *kindLogger) V(level kindlog.Level) kindlog.InfoLogger {
+	return &kindInfoLogger{log: l.log.V(int(level))}
+}
+
+type kindInfoLogger struct {
+	log logr.Logger
+}
+
+func (l *kindInfoLogger) Info(message string) {
+	l.log.Info(message)
+}
+
+func (l *kindInfoLogger) Infof(format string, args ...interface{}) {
+	l.log.Info(fmt.Sprintf(format, args...))
+}
+
+func (l *kindInfoLogger) Enabled() bool {
+	return l.log.Enabled()
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	rtypes "github.com/RasaHQ/rasactl/pkg/types"
+)
+
+// LocalClusterInterface defines a backend for a local Kubernetes cluster, e.g. kind, k3d or minikube.
+// The backend is used to mount a local project directory into the cluster and to reach
+// the local machine from pods.
+type LocalClusterInterface interface {
+	// GetType returns the type of the local cluster.
+	GetType() rtypes.LocalClusterType
+
+	// GetNodeName returns a name of the Kubernetes node dedicated to the current namespace.
+	// An empty string is returned if the backend doesn't use dedicated nodes.
+	GetNodeName() string
+
+	// CreateNode creates a node that has access to the project path and joins it to the cluster.
+	CreateNode() error
+
+	// StartNode starts a node that was previously stopped.
+	StartNode() error
+
+	// StopNode stops a node.
+	StopNode() error
+
+	// DeleteNode deletes a node.
+	DeleteNode() error
+
+	// GetNetworkGatewayAddress returns an address that pods use to reach the local machine.
+	GetNetworkGatewayAddress() (string, error)
+
+	// GetNodePortHost returns an address under which node ports are available on the local machine.
+	GetNodePortHost() (string, error)
+
+	// LoadImages loads images from a file in the docker save format into the cluster nodes.
+	LoadImages(file string) error
+}
+
+// LocalClusterSpec stores information about a control plane node of a local cluster.
+type LocalClusterSpec struct {
+	// Type is the type of the local cluster.
+	Type rtypes.LocalClusterType
+
+	// ControlPlaneHost is a name of the control plane node.
+	ControlPlaneHost string
+
+	// Version is a kubelet version of the control plane node.
+	Version string
+
+	// Labels stores labels of the control plane node.
+	Labels map[string]string
+}
+
+// DetectLocalClusterType detects the type of a local cluster by using the control plane node.
+func DetectLocalClusterType(node v1.Node) rtypes.LocalClusterType {
+	switch {
+	case node.Name == "":
+		return rtypes.LocalClusterUnknown
+	case node.Labels[minikubeProfileLabel] != "":
+		return rtypes.LocalClusterMinikube
+	case strings.HasPrefix(node.Spec.ProviderID, "k3s://") && strings.HasPrefix(node.Name, "k3d-"):
+		return rtypes.LocalClusterK3d
+	case strings.HasPrefix(node.Spec.ProviderID, "kind://") || strings.HasSuffix(node.Name, "-control-plane"):
+		return rtypes.LocalClusterKind
+	}
+
+	return rtypes.LocalClusterUnknown
+}
+
+// NewLocalCluster returns a backend for a given local cluster.
+// It returns nil if the cluster type is unknown.
+func NewLocalCluster(d *Docker, spec LocalClusterSpec) LocalClusterInterface {
+	d.Log.Info("Using local cluster backend", "type", spec.Type, "controlPlane", spec.ControlPlaneHost)
+
+	switch spec.Type {
+	case rtypes.LocalClusterKind:
+		d.SetKind(KindSpec{
+			ControlPlaneHost: spec.ControlPlaneHost,
+			Version:          spec.Version,
+		})
+		return &kindCluster{docker: d}
+	case rtypes.LocalClusterK3d:
+		return &k3dCluster{docker: d, spec: spec}
+	case rtypes.LocalClusterMinikube:
+		return &minikubeCluster{docker: d, profile: spec.Labels[minikubeProfileLabel]}
+	}
+
+	return nil
+}
+
+// kindCluster is a backend for a kind cluster. A project directory is mounted
+// into a dedicated kind node that joins the cluster.
+type kindCluster struct {
+	docker *Docker
+}
+
+func (k *kindCluster) GetType() rtypes.LocalClusterType {
+	return rtypes.LocalClusterKind
+}
+
+func (k *kindCluster) GetNodeName() string {
+	return fmt.Sprintf("kind-%s", k.docker.Namespace)
+}
+
+func (k *kindCluster) CreateNode() error {
+	_, err := k.docker.CreateKindNode(k.GetNodeName())
+	return err
+}
+
+func (k *kindCluster) StartNode() error {
+	return k.docker.StartKindNode(k.GetNodeName())
+}
+
+func (k *kindCluster) StopNode() error {
+	return k.docker.StopKindNode(k.GetNodeName())
+}
+
+func (k *kindCluster) DeleteNode() error {
+	return k.docker.DeleteKindNode(k.GetNodeName())
+}
+
+func (k *kindCluster) GetNetworkGatewayAddress() (string, error) {
+	return k.docker.GetKindNetworkGatewayAddress()
+}
+
+func (k *kindCluster) GetNodePortHost() (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (k *kindCluster) LoadImages(file string) error {
+	return k.docker.LoadImages(file)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	rtypes "github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	// minikubeProfileLabel is a label that minikube sets on nodes to store a profile name.
+	minikubeProfileLabel = "minikube.k8s.io/name"
+
+	// minikubeHostAlias is a hostname that minikube uses to resolve the host machine address.
+	minikubeHostAlias = "host.minikube.internal"
+
+	// minikubeMountTimeout defines how long to wait for a minikube mount to be ready.
+	minikubeMountTimeout = time.Minute * 1
+)
+
+// minikubeCluster is a backend for a minikube cluster. Minikube doesn't use dedicated nodes,
+// a project directory is mounted into the minikube node by a 'minikube mount' process
+// running in the background.
+type minikubeCluster struct {
+	docker  *Docker
+	profile string
+}
+
+func (m *minikubeCluster) GetType() rtypes.LocalClusterType {
+	return rtypes.LocalClusterMinikube
+}
+
+func (m *minikubeCluster) GetNodeName() string {
+	return ""
+}
+
+func (m *minikubeCluster) CreateNode() error {
+	return m.startMount()
+}
+
+func (m *minikubeCluster) StartNode() error {
+	return m.startMount()
+}
+
+func (m *minikubeCluster) StopNode() error {
+	return m.stopMount()
+}
+
+func (m *minikubeCluster) DeleteNode() error {
+	if err := m.stopMount(); err != nil {
+		return err
+	}
+	os.Remove(m.mountLogFile())
+	return nil
+}
+
+// GetNetworkGatewayAddress returns the address of the host.minikube.internal host.
+func (m *minikubeCluster) GetNetworkGatewayAddress() (string, error) {
+	output, err := m.run("ssh", "--", "grep", minikubeHostAlias, "/etc/hosts")
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", xerrors.Errorf("can't find the %s address in the minikube node", minikubeHostAlias)
+	}
+
+	return fields[0], nil
+}
+
+// GetNodePortHost returns the address of the minikube node.
+func (m *minikubeCluster) GetNodePortHost() (string, error) {
+	output, err := m.run("ip")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+func (m *minikubeCluster) LoadImages(file string) error {
+	m.docker.Log.Info("Loading images into a minikube node", "profile", m.profile, "file", file)
+	_, err := m.run("image", "load", "--daemon=false", file)
+	return err
+}
+
+// startMount starts the 'minikube mount' process in the background and waits until
+// the project path is mounted in the minikube node.
+func (m *minikubeCluster) startMount() error {
+	projectPath := m.docker.ProjectPath
+	if projectPath == "" {
+		return nil
+	}
+
+	if err := m.stopMount(); err != nil {
+		return err
+	}
+
+	binary, err := exec.LookPath("minikube")
+	if err != nil {
+		return xerrors.Errorf("can't find the minikube binary, it's required to mount a project directory: %w", err)
+	}
+
+	logFile, err := os.Create(m.mountLogFile())
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(binary, "mount", "--profile", m.profile, fmt.Sprintf("%s:%s", projectPath, projectPath))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	// Run the mount in a new session, so that signals sent to rasactl, e.g. when the terminal is closed, don't stop it.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	m.docker.Log.Info("Starting minikube mount", "profile", m.profile, "path", projectPath, "log", m.mountLogFile())
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(m.mountPidFile(), []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+		return err
+	}
+
+	// The process keeps running after rasactl exits, it's stopped by stopMount.
+	if err := cmd.Process.Release(); err != nil {
+		return err
+	}
+
+	m.docker.Spinner.Message("Waiting for the project directory to be mounted in minikube")
+	deadline := time.Now().Add(minikubeMountTimeout)
+	for time.Now().Before(deadline) {
+		if _, err := m.run("ssh", "--", "mountpoint", "-q", projectPath); err == nil {
+			return nil
+		}
+		time.Sleep(time.Second * 2)
+	}
+
+	return xerrors.Errorf("the %s directory is not mounted in minikube after %s, see %s for details",
+		projectPath, minikubeMountTimeout, m.mountLogFile())
+}
+
+// stopMount stops the 'minikube mount' process started for the current namespace.
+func (m *minikubeCluster) stopMount() error {
+	data, err := ioutil.ReadFile(m.mountPidFile())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+
+	// The process could have exited and its PID could have been reused by another process,
+	// only the 'minikube mount' process for the current profile is stopped.
+	if !m.isMountProcess(pid) {
+		m.docker.Log.V(1).Info("The minikube mount process is not running", "profile", m.profile, "pid", pid)
+		return os.Remove(m.mountPidFile())
+	}
+
+	m.docker.Log.Info("Stopping minikube mount", "profile", m.profile, "pid", pid)
+	if process, err := os.FindProcess(pid); err == nil {
+		if err := process.Kill(); err != nil {
+			m.docker.Log.V(1).Info("Can't stop minikube mount", "pid", pid, "error", err)
+		}
+	}
+
+	return os.Remove(m.mountPidFile())
+}
+
+// isMountProcess checks if a process with a given PID is the 'minikube mount' process for the cluster profile.
+func (m *minikubeCluster) isMountProcess(pid int) bool {
+	var args []string
+	if data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid)); err == nil {
+		args = strings.Split(strings.TrimRight(string(data), "\x00"), "\x00")
+	} else if runtime.GOOS != "linux" {
+		output, err := exec.Command("ps", "-o", "args=", "-p", strconv.Itoa(pid)).Output()
+		if err != nil {
+			return false
+		}
+		args = strings.Fields(string(output))
+	}
+
+	if len(args) < 4 || filepath.Base(args[0]) != "minikube" || args[1] != "mount" {
+		return false
+	}
+
+	for i := 2; i < len(args)-1; i++ {
+		if args[i] == "--profile" && args[i+1] == m.profile {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *minikubeCluster) mountPidFile() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("rasactl-minikube-mount-%s.pid", m.docker.Namespace))
+}
+
+func (m *minikubeCluster) mountLogFile() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("rasactl-minikube-mount-%s.log", m.docker.Namespace))
+}
+
+// run executes a minikube command for the cluster profile and returns its output.
+func (m *minikubeCluster) run(args ...string) (string, error) {
+	args = append([]string{"--profile", m.profile}, args...)
+	output, err := exec.Command("minikube", args...).CombinedOutput()
+	if err != nil {
+		return "", xerrors.Errorf("minikube %s: %s: %w", strings.Join(args, " "), strings.TrimSpace(string(output)), err)
+	}
+	return string(output), nil
+}
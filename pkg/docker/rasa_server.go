@@ -0,0 +1,204 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package docker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// RasaServerWorkDir is a directory in a Rasa server container where the project directory is mounted.
+	RasaServerWorkDir = "/app"
+
+	// rasaServerLabel is a label set for containers that run a Rasa server.
+	rasaServerLabel = "rasactl.rasa-server"
+)
+
+// RasaServerSpec stores a specification of a Rasa server that runs as a container.
+type RasaServerSpec struct {
+	// Name is a name of the container.
+	Name string
+
+	// Image is a Rasa OSS image, e.g. rasa/rasa:2.8.15.
+	Image string
+
+	// ProjectPath is a path to a directory that is mounted in the container as the working directory.
+	ProjectPath string
+
+	// Entrypoint overrides the entrypoint of the image, the image entrypoint is used if it's empty.
+	Entrypoint []string
+
+	// Args are arguments passed to the rasa command, or to the entrypoint if it's set.
+	Args []string
+
+	// Port is a port the Rasa server listens on, it's 0 if the container doesn't run a server.
+	Port int
+}
+
+// RasaServerContainerName returns a name of the container that runs a Rasa server
+// for a given deployment and environment.
+func RasaServerContainerName(deployment, environment string) string {
+	return fmt.Sprintf("rasactl-%s-rasa-%s", deployment, environment)
+}
+
+// RasaServerHost returns an address of the local machine that is accessible from a Rasa server container.
+// The container uses the host network on Linux, on other systems the host is accessible via host.docker.internal.
+func RasaServerHost() string {
+	if runtime.GOOS == "linux" {
+		return "127.0.0.1"
+	}
+	return "host.docker.internal"
+}
+
+// RunRasaServer creates and starts a container that runs a Rasa server,
+// an existing container with the same name is replaced.
+func (d *Docker) RunRasaServer(spec RasaServerSpec) error {
+	if err := d.DeleteRasaServer(spec.Name); err != nil {
+		return err
+	}
+
+	if err := d.ensureImage(spec.Image); err != nil {
+		return err
+	}
+
+	config, hostConfig := rasaServerContainerConfig(d.Namespace, spec)
+
+	d.Log.Info("Creating a Rasa server container", "name", spec.Name, "image", spec.Image)
+	if _, err := d.Client.ContainerCreate(d.Ctx, config, hostConfig, nil, nil, spec.Name); err != nil {
+		return err
+	}
+
+	return d.Client.ContainerStart(d.Ctx, spec.Name, types.ContainerStartOptions{})
+}
+
+// DeleteRasaServer removes a container that runs a Rasa server, it's a no-op if the container doesn't exist.
+func (d *Docker) DeleteRasaServer(name string) error {
+	d.Log.V(1).Info("Removing a Rasa server container", "name", name)
+	err := d.Client.ContainerRemove(d.Ctx, name, types.ContainerRemoveOptions{Force: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// RunRasaCommand runs the rasa command with given arguments in a container, e.g. to train a model.
+// Output of the command is written to a given writer, the container is removed once the command exits.
+func (d *Docker) RunRasaCommand(spec RasaServerSpec, output io.Writer) error {
+	if err := d.DeleteRasaServer(spec.Name); err != nil {
+		return err
+	}
+
+	if err := d.ensureImage(spec.Image); err != nil {
+		return err
+	}
+
+	config, hostConfig := rasaServerContainerConfig(d.Namespace, spec)
+	if runtime.GOOS == "linux" {
+		// Files created in the project directory have to be owned by the current user.
+		config.User = fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	}
+
+	d.Log.Info("Running the rasa command in a container", "name", spec.Name, "image", spec.Image, "args", spec.Args)
+	if _, err := d.Client.ContainerCreate(d.Ctx, config, hostConfig, nil, nil, spec.Name); err != nil {
+		return err
+	}
+	defer func() {
+		if err := d.DeleteRasaServer(spec.Name); err != nil {
+			d.Log.Error(err, "Can't remove the container", "name", spec.Name)
+		}
+	}()
+
+	statusCh, errCh := d.Client.ContainerWait(d.Ctx, spec.Name, container.WaitConditionNextExit)
+	if err := d.Client.ContainerStart(d.Ctx, spec.Name, types.ContainerStartOptions{}); err != nil {
+		return err
+	}
+
+	logs, err := d.GetContainerLogs(spec.Name, types.ContainerLogsOptions{Follow: true})
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	if _, err := io.Copy(output, logs); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case status := <-statusCh:
+		if status.StatusCode != 0 {
+			return xerrors.Errorf("the rasa command has exited with code %d", status.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// ensureImage pulls a given image if it doesn't exist locally.
+func (d *Docker) ensureImage(image string) error {
+	_, _, err := d.Client.ImageInspectWithRaw(d.Ctx, image)
+	if err == nil {
+		return nil
+	}
+	if !client.IsErrNotFound(err) {
+		return err
+	}
+
+	return d.pullImage(image)
+}
+
+// rasaServerContainerConfig returns configuration for a container that runs the rasa command.
+func rasaServerContainerConfig(deployment string, spec RasaServerSpec) (*container.Config, *container.HostConfig) {
+	config := &container.Config{
+		Image:      spec.Image,
+		Entrypoint: spec.Entrypoint,
+		Cmd:        spec.Args,
+		WorkingDir: RasaServerWorkDir,
+		Labels:     map[string]string{rasaServerLabel: deployment},
+	}
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: spec.ProjectPath,
+				Target: RasaServerWorkDir,
+			},
+		},
+	}
+
+	if runtime.GOOS == "linux" {
+		hostConfig.NetworkMode = "host"
+	} else {
+		if spec.Port != 0 {
+			publishPort(config, hostConfig, nat.Port(fmt.Sprintf("%d/tcp", spec.Port)), spec.Port)
+		}
+		hostConfig.ExtraHosts = []string{"host.docker.internal:host-gateway"}
+	}
+
+	return config, hostConfig
+}
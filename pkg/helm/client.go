@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/viper"
@@ -49,14 +50,22 @@ type Interface interface {
 	Upgrade() error
 	ReadValuesFile() error
 	GetAllValues() (map[string]interface{}, error)
+	GetUserValues() (map[string]interface{}, error)
 	IsDeployed() (bool, error)
 	GetStatus() (*release.Release, error)
+	History(max int) ([]*release.Release, error)
+	Rollback(revision int) error
 	SetConfiguration(config *types.HelmConfigurationSpec)
 	GetConfiguration() *types.HelmConfigurationSpec
 	GetValues() map[string]interface{}
 	SetValues(values map[string]interface{})
+	GetManifest() string
+	GetImages() ([]string, error)
+	SaveChart(dir string) (string, string, error)
 	SetKubernetesBackendType(backend types.KubernetesBackendType)
 	SetPersistanceVolumeClaimName(name string)
+	SetUseDedicatedNode(use bool)
+	SetUseSyncPod(use bool)
 }
 
 // Helm represents a helm client.
@@ -73,10 +82,18 @@ type Helm struct {
 	// PVCName defines a persistent volume claim name that is used to create a PVC.
 	PVCName string
 
+	// UseDedicatedNode defines if pods that use a local project are scheduled on a dedicated node.
+	UseDedicatedNode bool
+
+	// UseSyncPod defines if a local project is synced into the volume by the helper pod,
+	// pods that use the volume are scheduled on the same node as the helper pod.
+	UseSyncPod bool
+
 	// KubernetesBackendType defines a Kubernetes cluster type.
 	KubernetesBackendType types.KubernetesBackendType
 
 	// Repositories store slices of helm repository that used used by the client.
+	// The first repository is used as a source of the rasa-x helm chart.
 	Repositories []types.RepositorySpec
 
 	// Configuration defines configuration for the client.
@@ -100,6 +117,9 @@ type Helm struct {
 	// Values store helm values that are used by the client.
 	Values map[string]interface{}
 
+	// Release stores a helm release returned by the last install or upgrade.
+	Release *release.Release
+
 	// CloudProvider stores information about a cloud provider.
 	CloudProvider *cloud.Provider
 
@@ -124,10 +144,19 @@ func New(client *Helm) (Interface, error) {
 
 	client.Log.Info("Initializing Helm client")
 
-	client.Repositories = append(client.Repositories, types.RepositorySpec{
-		Name: "rasa-x",
-		URL:  "https://rasahq.github.io/rasa-x-helm",
-	})
+	// Use helm repositories defined in the configuration file, the first repository
+	// is used as a source of the rasa-x helm chart.
+	if err := viper.UnmarshalKey("repositories", &client.Repositories); err != nil {
+		return nil, xerrors.Errorf("can't parse repositories defined in the configuration file: %w", err)
+	}
+
+	if len(client.Repositories) == 0 {
+		client.Repositories = append(client.Repositories, types.RepositorySpec{
+			Name: types.HelmChartNameRasaX,
+			URL:  types.HelmRepositoryURLRasaX,
+		})
+	}
+	client.Log.V(1).Info("Using helm repositories", "repositories", client.Repositories)
 
 	client.ActionConfig = new(action.Configuration)
 
@@ -172,8 +201,10 @@ func (h *Helm) addRepository() ([]*repo.ChartRepository, error) {
 
 	for _, repEntry := range h.Repositories {
 		rep := repo.Entry{
-			Name: repEntry.Name,
-			URL:  repEntry.URL,
+			Name:     repEntry.Name,
+			URL:      repEntry.URL,
+			Username: repEntry.Username,
+			Password: repEntry.Password,
 		}
 		r, err := repo.NewChartRepository(&rep, getter.All(h.Settings))
 		r.CachePath = h.Settings.RepositoryCache
@@ -212,3 +243,35 @@ func (h *Helm) updateRepository() error {
 
 	return g.Wait()
 }
+
+// locateChart returns a path to the rasa-x helm chart.
+//
+// If the --chart flag is used, the chart is loaded from a local directory, a chart archive
+// or an OCI registry, and helm repositories are not updated. Otherwise, the chart is downloaded
+// from the first configured helm repository.
+func (h *Helm) locateChart() (string, error) {
+	co := action.ChartPathOptions{
+		InsecureSkipTLSverify: false,
+		Version:               h.Configuration.Version,
+	}
+	name := h.RasaXChartName
+
+	if chart := h.Flags.StartUpgrade.Chart; chart != "" {
+		name = chart
+		if !strings.HasPrefix(chart, "oci://") {
+			if _, err := os.Stat(chart); err != nil {
+				return "", xerrors.Errorf("can't use the %s helm chart: %w", chart, err)
+			}
+		}
+	} else {
+		if err := h.updateRepository(); err != nil {
+			return "", err
+		}
+		co.RepoURL = h.Repositories[0].URL
+		co.Username = h.Repositories[0].Username
+		co.Password = h.Repositories[0].Password
+	}
+
+	h.Log.V(1).Info("Locating helm chart", "chart", name, "version", co.Version, "repository", co.RepoURL)
+	return co.LocateChart(name, h.Settings)
+}
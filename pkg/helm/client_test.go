@@ -0,0 +1,83 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/logger"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+var _ = Describe("Client", func() {
+
+	var flags *types.RasaCtlFlags
+
+	newClient := func() *helm.Helm {
+		client, err := helm.New(
+			&helm.Helm{
+				Namespace: "test-namespace",
+				Flags:     flags,
+				Log:       logger.New(flags),
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		return client.(*helm.Helm)
+	}
+
+	BeforeEach(func() {
+		flags = &types.RasaCtlFlags{}
+	})
+
+	AfterEach(func() {
+		viper.Set("repositories", nil)
+	})
+
+	Describe("Repositories", func() {
+		It("uses the default repository", func() {
+			client := newClient()
+			Expect(client.Repositories).To(Equal([]types.RepositorySpec{
+				{Name: types.HelmChartNameRasaX, URL: types.HelmRepositoryURLRasaX},
+			}))
+		})
+
+		It("uses repositories from the configuration file", func() {
+			viper.Set("repositories", []map[string]interface{}{
+				{"name": "rasa-x-fork", "url": "https://charts.example.com", "username": "user"},
+			})
+
+			client := newClient()
+			Expect(client.Repositories).To(Equal([]types.RepositorySpec{
+				{Name: "rasa-x-fork", URL: "https://charts.example.com", Username: "user"},
+			}))
+		})
+	})
+
+	Describe("Chart", func() {
+		It("returns an error if a local chart doesn't exist", func() {
+			flags.StartUpgrade.Chart = "../../testdata/not-existing-chart"
+
+			client := newClient()
+			client.SetConfiguration(&types.HelmConfigurationSpec{ReleaseName: "rasa-x"})
+			err := client.Upgrade()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't use the ../../testdata/not-existing-chart helm chart"))
+		})
+	})
+})
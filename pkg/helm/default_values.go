@@ -15,7 +15,11 @@ limitations under the License.
 */
 package helm
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
 
 func valuesMountHostPath(pvcName string) map[string]interface{} {
 	values := map[string]interface{}{
@@ -68,7 +72,7 @@ func valuesDisableRasaWorker() map[string]interface{} {
 	return values
 }
 
-func valuesUseDedicatedKindNode(namespace string) map[string]interface{} {
+func valuesUseDedicatedNode(namespace string) map[string]interface{} {
 	values := map[string]interface{}{
 		"rasax": map[string]interface{}{
 			"tolerations": []map[string]interface{}{
@@ -88,6 +92,31 @@ func valuesUseDedicatedKindNode(namespace string) map[string]interface{} {
 	return values
 }
 
+// valuesSyncPodAffinity schedules the rasa-x pod on the same node as the sync pod,
+// a volume with the ReadWriteOnce access mode can be mounted only by a single node.
+func valuesSyncPodAffinity() map[string]interface{} {
+	values := map[string]interface{}{
+		"rasax": map[string]interface{}{
+			"affinity": map[string]interface{}{
+				"podAffinity": map[string]interface{}{
+					"requiredDuringSchedulingIgnoredDuringExecution": []map[string]interface{}{
+						{
+							"labelSelector": map[string]interface{}{
+								"matchLabels": map[string]interface{}{
+									types.SyncPodLabel: "true",
+								},
+							},
+							"topologyKey": "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return values
+}
+
 func valuesDisableNginx() map[string]interface{} {
 
 	values := map[string]interface{}{
@@ -220,6 +249,42 @@ func ValuesSetRasaXHostAliases(ipAddress string) map[string]interface{} {
 	return values
 }
 
+// ValuesActionEndpoint returns helm values which configure the deployment to use an external action server
+// instead of the action server deployed by the helm chart.
+func ValuesActionEndpoint(url string) map[string]interface{} {
+	values := map[string]interface{}{
+		"app": map[string]interface{}{
+			"install":     false,
+			"existingUrl": url,
+		},
+	}
+
+	return values
+}
+
+// ValuesRasaServer returns helm values which enable or disable the rasa production and rasa worker deployments.
+// The Rasa version is set only if a given version is not empty.
+func ValuesRasaServer(enabled bool, version string) map[string]interface{} {
+	rasa := map[string]interface{}{
+		"versions": map[string]interface{}{
+			"rasaProduction": map[string]interface{}{
+				"enabled": enabled,
+			},
+			"rasaWorker": map[string]interface{}{
+				"enabled": enabled,
+			},
+		},
+	}
+
+	if version != "" {
+		rasa["version"] = version
+	}
+
+	return map[string]interface{}{
+		"rasa": rasa,
+	}
+}
+
 func valuesRabbitMQErlangCookie() map[string]interface{} {
 	values := map[string]interface{}{
 		"rabbitmq": map[string]interface{}{
@@ -247,3 +312,62 @@ func valuesUseEdgeReleaseRasaX() map[string]interface{} {
 
 	return values
 }
+
+// managedValuePaths are paths of values that rasactl sets when a deployment is created,
+// or when the Rasa server is enabled by the 'rasactl rasa enable' command.
+var managedValuePaths = [][]string{
+	{"rasa", "version"},
+	{"rasa", "versions", "rasaProduction", "enabled"},
+	{"rasa", "versions", "rasaWorker", "enabled"},
+	{"rabbitmq", "auth", "erlangCookie"},
+	{"rasax", "initialUser", "password"},
+	{"rasax", "extraVolumes"},
+	{"rasax", "extraVolumeMounts"},
+	{"rasax", "tolerations"},
+	{"rasax", "nodeSelector"},
+	{"rasax", "affinity"},
+	{"nginx", "enabled"},
+	{"nginx", "service", "type"},
+	{"ingress", "enabled"},
+	{"ingress", "hosts"},
+}
+
+// ManagedValues returns values managed by rasactl from given values of a helm release,
+// e.g. the mounted project, the local ingress, or the Rasa X password. Other values are skipped.
+func ManagedValues(values map[string]interface{}) map[string]interface{} {
+	managed := map[string]interface{}{}
+
+	for _, path := range managedValuePaths {
+		value, ok := lookupValue(values, path)
+		if !ok {
+			continue
+		}
+
+		current := managed
+		for _, key := range path[:len(path)-1] {
+			next, ok := current[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				current[key] = next
+			}
+			current = next
+		}
+		current[path[len(path)-1]] = value
+	}
+
+	return managed
+}
+
+func lookupValue(values map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = values
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
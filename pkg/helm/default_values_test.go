@@ -0,0 +1,67 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+)
+
+var _ = Describe("Managed values", func() {
+
+	It("returns only values managed by rasactl", func() {
+		values := map[string]interface{}{
+			"rasax": map[string]interface{}{
+				"initialUser": map[string]interface{}{
+					"password": "password",
+					"username": "me",
+				},
+				"tag": "1.0.0",
+			},
+			"rabbitmq": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"erlangCookie": "cookie",
+				},
+			},
+			"nginx": map[string]interface{}{
+				"enabled": false,
+			},
+			"ingress": "not a map",
+		}
+
+		Expect(helm.ManagedValues(values)).To(Equal(map[string]interface{}{
+			"rasax": map[string]interface{}{
+				"initialUser": map[string]interface{}{
+					"password": "password",
+				},
+			},
+			"rabbitmq": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"erlangCookie": "cookie",
+				},
+			},
+			"nginx": map[string]interface{}{
+				"enabled": false,
+			},
+		}))
+	})
+
+	It("returns empty values if there are no managed values", func() {
+		Expect(helm.ManagedValues(nil)).To(BeEmpty())
+	})
+})
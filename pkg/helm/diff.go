@@ -0,0 +1,83 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ManifestDiff returns a unified diff between two helm manifests.
+// The manifests are split into Kubernetes resources and the diff is generated per resource.
+// Values of Secret resources are redacted. An empty string is returned if there are no changes.
+func ManifestDiff(current, rendered string) (string, error) {
+	currentResources, err := splitManifest(current)
+	if err != nil {
+		return "", err
+	}
+
+	renderedResources, err := splitManifest(rendered)
+	if err != nil {
+		return "", err
+	}
+
+	keys := []string{}
+	for key := range currentResources {
+		keys = append(keys, key)
+	}
+	for key := range renderedResources {
+		if _, ok := currentResources[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diff strings.Builder
+	for _, key := range keys {
+		currentResource, renderedResource := currentResources[key], renderedResources[key]
+
+		// Values of secrets are not printed, only keys with changed values are marked.
+		if strings.HasPrefix(key, "Secret/") {
+			if currentResource != "" {
+				if currentResource, err = redactSecret(currentResources[key], renderedResources[key], "(before)"); err != nil {
+					return "", err
+				}
+			}
+			if renderedResource != "" {
+				if renderedResource, err = redactSecret(renderedResources[key], currentResources[key], "(after)"); err != nil {
+					return "", err
+				}
+			}
+		}
+
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(currentResource),
+			B:        difflib.SplitLines(renderedResource),
+			FromFile: fmt.Sprintf("current/%s", key),
+			ToFile:   fmt.Sprintf("rendered/%s", key),
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		diff.WriteString(text)
+	}
+
+	return diff.String(), nil
+}
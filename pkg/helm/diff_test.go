@@ -0,0 +1,107 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+var _ = Describe("Diff", func() {
+
+	const current = `---
+# Source: rasa-x/templates/rasa-x-deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: rasa-x
+spec:
+  replicas: 1
+---
+# Source: rasa-x/templates/rasa-x-service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: rasa-x
+`
+
+	It("returns an empty diff if manifests are the same", func() {
+		diff, err := helm.ManifestDiff(current, current)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+
+	It("returns a diff per resource", func() {
+		rendered := `---
+# Source: rasa-x/templates/rasa-x-deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: rasa-x
+spec:
+  replicas: 2
+---
+# Source: rasa-x/templates/rasa-x-service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: rasa-x
+---
+# Source: rasa-x/templates/rasa-x-secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: rasa-x
+`
+		diff, err := helm.ManifestDiff(current, rendered)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(ContainSubstring("--- current/Deployment/rasa-x"))
+		Expect(diff).To(ContainSubstring("-  replicas: 1\n+  replicas: 2"))
+		Expect(diff).To(ContainSubstring("+++ rendered/Secret/rasa-x"))
+		Expect(diff).NotTo(ContainSubstring("Service/rasa-x"))
+	})
+
+	It("redacts values of secrets", func() {
+		secret := `---
+# Source: rasa-x/templates/rasa-x-secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: rasa-x
+data:
+  password: %s
+  token: dG9rZW4=
+`
+		diff, err := helm.ManifestDiff(fmt.Sprintf(secret, "b2xk"), fmt.Sprintf(secret, "bmV3"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).NotTo(ContainSubstring("b2xk"))
+		Expect(diff).NotTo(ContainSubstring("bmV3"))
+		Expect(diff).NotTo(ContainSubstring("dG9rZW4="))
+		Expect(diff).To(ContainSubstring("-  password: " + utils.RedactedValue + " (before)"))
+		Expect(diff).To(ContainSubstring("+  password: " + utils.RedactedValue + " (after)"))
+		Expect(diff).To(ContainSubstring("   token: " + utils.RedactedValue))
+
+		diff, err = helm.ManifestDiff("", fmt.Sprintf(secret, "bmV3"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).NotTo(ContainSubstring("bmV3"))
+		Expect(diff).To(ContainSubstring("+  password: " + utils.RedactedValue + "\n"))
+	})
+})
@@ -65,6 +65,35 @@ func (mr *MockInterfaceMockRecorder) GetConfiguration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfiguration", reflect.TypeOf((*MockInterface)(nil).GetConfiguration))
 }
 
+// GetImages mocks base method.
+func (m *MockInterface) GetImages() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImages")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImages indicates an expected call of GetImages.
+func (mr *MockInterfaceMockRecorder) GetImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImages", reflect.TypeOf((*MockInterface)(nil).GetImages))
+}
+
+// GetManifest mocks base method.
+func (m *MockInterface) GetManifest() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManifest")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetManifest indicates an expected call of GetManifest.
+func (mr *MockInterfaceMockRecorder) GetManifest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManifest", reflect.TypeOf((*MockInterface)(nil).GetManifest))
+}
+
 // GetNamespace mocks base method.
 func (m *MockInterface) GetNamespace() string {
 	m.ctrl.T.Helper()
@@ -94,6 +123,21 @@ func (mr *MockInterfaceMockRecorder) GetStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockInterface)(nil).GetStatus))
 }
 
+// GetUserValues mocks base method.
+func (m *MockInterface) GetUserValues() (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserValues")
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserValues indicates an expected call of GetUserValues.
+func (mr *MockInterfaceMockRecorder) GetUserValues() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserValues", reflect.TypeOf((*MockInterface)(nil).GetUserValues))
+}
+
 // GetValues mocks base method.
 func (m *MockInterface) GetValues() map[string]interface{} {
 	m.ctrl.T.Helper()
@@ -108,6 +152,21 @@ func (mr *MockInterfaceMockRecorder) GetValues() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValues", reflect.TypeOf((*MockInterface)(nil).GetValues))
 }
 
+// History mocks base method.
+func (m *MockInterface) History(arg0 int) ([]*release.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", arg0)
+	ret0, _ := ret[0].([]*release.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockInterfaceMockRecorder) History(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockInterface)(nil).History), arg0)
+}
+
 // Install mocks base method.
 func (m *MockInterface) Install() error {
 	m.ctrl.T.Helper()
@@ -151,6 +210,36 @@ func (mr *MockInterfaceMockRecorder) ReadValuesFile() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadValuesFile", reflect.TypeOf((*MockInterface)(nil).ReadValuesFile))
 }
 
+// Rollback mocks base method.
+func (m *MockInterface) Rollback(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockInterfaceMockRecorder) Rollback(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockInterface)(nil).Rollback), arg0)
+}
+
+// SaveChart mocks base method.
+func (m *MockInterface) SaveChart(arg0 string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChart", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveChart indicates an expected call of SaveChart.
+func (mr *MockInterfaceMockRecorder) SaveChart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChart", reflect.TypeOf((*MockInterface)(nil).SaveChart), arg0)
+}
+
 // SetConfiguration mocks base method.
 func (m *MockInterface) SetConfiguration(arg0 *types.HelmConfigurationSpec) {
 	m.ctrl.T.Helper()
@@ -201,6 +290,30 @@ func (mr *MockInterfaceMockRecorder) SetPersistanceVolumeClaimName(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPersistanceVolumeClaimName", reflect.TypeOf((*MockInterface)(nil).SetPersistanceVolumeClaimName), arg0)
 }
 
+// SetUseDedicatedNode mocks base method.
+func (m *MockInterface) SetUseDedicatedNode(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUseDedicatedNode", arg0)
+}
+
+// SetUseDedicatedNode indicates an expected call of SetUseDedicatedNode.
+func (mr *MockInterfaceMockRecorder) SetUseDedicatedNode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUseDedicatedNode", reflect.TypeOf((*MockInterface)(nil).SetUseDedicatedNode), arg0)
+}
+
+// SetUseSyncPod mocks base method.
+func (m *MockInterface) SetUseSyncPod(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUseSyncPod", arg0)
+}
+
+// SetUseSyncPod indicates an expected call of SetUseSyncPod.
+func (mr *MockInterfaceMockRecorder) SetUseSyncPod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUseSyncPod", reflect.TypeOf((*MockInterface)(nil).SetUseSyncPod), arg0)
+}
+
 // SetValues mocks base method.
 func (m *MockInterface) SetValues(arg0 map[string]interface{}) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,46 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm
+
+import (
+	"sort"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// History returns revisions of the helm release sorted from the oldest to the newest one.
+func (h *Helm) History(max int) ([]*release.Release, error) {
+	client := action.NewHistory(h.ActionConfig)
+	client.Max = max
+
+	releases, err := client.Run(h.Configuration.ReleaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Version < releases[j].Version
+	})
+
+	h.Log.V(1).Info("Getting release history",
+		"releaseName", h.Configuration.ReleaseName,
+		"namespace", h.Namespace,
+		"revisions", len(releases),
+	)
+
+	return releases, nil
+}
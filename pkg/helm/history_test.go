@@ -0,0 +1,76 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm_test
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/logger"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+var _ = Describe("History", func() {
+
+	var client *helm.Helm
+
+	BeforeEach(func() {
+		flags := &types.RasaCtlFlags{}
+		helmClient, err := helm.New(
+			&helm.Helm{
+				Namespace: "test-namespace",
+				Flags:     flags,
+				Log:       logger.New(flags),
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		client = helmClient.(*helm.Helm)
+		client.ActionConfig.Releases = storage.Init(driver.NewMemory())
+		client.ActionConfig.KubeClient = &kubefake.PrintingKubeClient{Out: ioutil.Discard}
+		client.SetConfiguration(&types.HelmConfigurationSpec{ReleaseName: "rasa-x"})
+
+		for _, version := range []int{3, 1, 2} {
+			rel := release.Mock(&release.MockReleaseOptions{
+				Name:      "rasa-x",
+				Version:   version,
+				Namespace: "test-namespace",
+			})
+			Expect(client.ActionConfig.Releases.Create(rel)).To(Succeed())
+		}
+	})
+
+	It("returns revisions sorted from the oldest one", func() {
+		releases, err := client.History(256)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(releases).To(HaveLen(3))
+		Expect(releases[0].Version).To(Equal(1))
+		Expect(releases[2].Version).To(Equal(3))
+	})
+
+	It("returns an error if the release doesn't exist", func() {
+		client.SetConfiguration(&types.HelmConfigurationSpec{ReleaseName: "not-existing"})
+		_, err := client.History(256)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
 
+	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
 	"github.com/RasaHQ/rasactl/pkg/utils"
 )
@@ -28,19 +29,8 @@ import (
 // Install prepares and executes the installation.
 func (h *Helm) Install() error {
 
-	err := h.updateRepository()
-	if err != nil {
-		return err
-	}
-
-	co := action.ChartPathOptions{
-		InsecureSkipTLSverify: false,
-		RepoURL:               h.Repositories[0].URL,
-		Version:               h.Configuration.Version,
-	}
-
 	h.Log.V(1).Info("Helm environment settings", "settings", h.Settings)
-	chartPath, err := co.LocateChart(h.RasaXChartName, h.Settings)
+	chartPath, err := h.locateChart()
 	if err != nil {
 		return err
 	}
@@ -50,8 +40,9 @@ func (h *Helm) Install() error {
 	client.Namespace = h.Namespace
 	client.ReleaseName = h.Configuration.ReleaseName
 	client.Description = "rasactl"
-	client.Wait = true
-	client.DryRun = false
+	client.Wait = !h.Configuration.DryRun
+	client.DryRun = h.Configuration.DryRun
+	client.ClientOnly = h.Configuration.ClientOnly
 	client.Timeout = h.Configuration.Timeout
 	client.Version = h.Configuration.Version
 
@@ -87,11 +78,30 @@ func (h *Helm) Install() error {
 		h.Values = utils.MergeMaps(valuesUseEdgeReleaseRasaX(), h.Values)
 	}
 
+	// Deploy the rasa production and rasa worker deployments with a Rasa version compatible with Rasa X
+	if h.Flags.Start.WithRasaServer {
+		rasaVersion := h.Flags.Start.RasaVersion
+		if rasaVersion == "" {
+			rasaVersion = valuesRasaVersion(h.Values)
+		}
+
+		version, err := status.CompatibleRasaVersion(valuesRasaXVersion(h.Values, helmChart), rasaVersion)
+		if err != nil {
+			return err
+		}
+		h.Values = utils.MergeMaps(h.Values, ValuesRasaServer(true, version))
+		h.Log.V(1).Info("Merging values", "result", h.Values)
+	}
+
 	// Add additional values for local PVC
-	if (h.Flags.Start.ProjectPath != "" || h.Flags.Start.Project) &&
-		h.KubernetesBackendType == types.KubernetesBackendLocal {
+	if (h.Flags.Start.ProjectPath != "" || h.Flags.Start.Project) && h.PVCName != "" {
 		h.Values = utils.MergeMaps(valuesMountHostPath(h.PVCName), h.Values)
-		h.Values = utils.MergeMaps(valuesUseDedicatedKindNode(h.Namespace), h.Values)
+		if h.UseDedicatedNode {
+			h.Values = utils.MergeMaps(valuesUseDedicatedNode(h.Namespace), h.Values)
+		}
+		if h.UseSyncPod {
+			h.Values = utils.MergeMaps(valuesSyncPodAffinity(), h.Values)
+		}
 		h.Log.V(1).Info("Merging values", "result", h.Values)
 	}
 
@@ -121,8 +131,12 @@ func (h *Helm) Install() error {
 		return err
 	}
 	h.setCacheDirectory(cachePath)
+	h.Release = rel
 
 	msg := fmt.Sprintf("Installation has beed finished, status: %s", rel.Info.Status)
+	if client.DryRun {
+		msg = "Dry run has been finished, no changes have been applied"
+	}
 	h.Log.Info(msg, "releaseName", client.ReleaseName, "namespace", client.Namespace)
 	h.Log.V(1).Info(msg, "values", h.Values)
 	h.Spinner.Message(msg)
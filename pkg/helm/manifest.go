@@ -0,0 +1,156 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+// ManifestImages returns a sorted list of container images used by resources in a helm manifest.
+func ManifestImages(manifest string) ([]string, error) {
+	images := map[string]bool{}
+
+	for _, content := range releaseutil.SplitManifests(manifest) {
+		resource := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(content), &resource); err != nil {
+			return nil, err
+		}
+		findImages(resource, images)
+	}
+
+	result := []string{}
+	for image := range images {
+		result = append(result, image)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// findImages looks for the 'image' field of containers and init containers.
+func findImages(data interface{}, images map[string]bool) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if key == "containers" || key == "initContainers" {
+				containers, _ := v.([]interface{})
+				for _, c := range containers {
+					container, _ := c.(map[string]interface{})
+					if image, ok := container["image"].(string); ok && image != "" {
+						images[image] = true
+					}
+				}
+				continue
+			}
+			findImages(v, images)
+		}
+	case []interface{}:
+		for _, v := range value {
+			findImages(v, images)
+		}
+	}
+}
+
+// splitManifest splits a helm manifest into resources, the 'Kind/name' string is used as a key.
+func splitManifest(manifest string) (map[string]string, error) {
+	resources := map[string]string{}
+
+	for _, content := range releaseutil.SplitManifests(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil {
+			return nil, err
+		}
+
+		if head.Metadata == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", head.Kind, head.Metadata.Name)
+		resources[key] = strings.TrimSpace(content) + "\n"
+	}
+
+	return resources, nil
+}
+
+// RedactManifest replaces values of Secret resources in a helm manifest with a placeholder.
+func RedactManifest(manifest string) (string, error) {
+	resources := []string{}
+
+	for _, content := range releaseutil.SplitManifests(manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil {
+			return "", err
+		}
+
+		if head.Kind != "Secret" {
+			resources = append(resources, strings.TrimSpace(content))
+			continue
+		}
+
+		redacted, err := redactSecret(content, "", "")
+		if err != nil {
+			return "", err
+		}
+		resources = append(resources, strings.TrimSpace(redacted))
+	}
+
+	return "---\n" + strings.Join(resources, "\n---\n") + "\n", nil
+}
+
+// redactSecret replaces values of the data and stringData fields of a Secret resource with a placeholder.
+// If another version of the resource is given, values that differ from the other version
+// are replaced with the placeholder followed by a given suffix, so changes are visible in a diff.
+func redactSecret(content, other, suffix string) (string, error) {
+	resource := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(content), &resource); err != nil {
+		return "", err
+	}
+
+	otherResource := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(other), &otherResource); err != nil {
+		return "", err
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := resource[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		otherData, _ := otherResource[field].(map[string]interface{})
+
+		for key, value := range data {
+			data[key] = utils.RedactedValue
+			if otherValue, exists := otherData[key]; other != "" && (!exists || !reflect.DeepEqual(otherValue, value)) {
+				data[key] = fmt.Sprintf("%s %s", utils.RedactedValue, suffix)
+			}
+		}
+	}
+
+	redacted, err := yaml.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+
+	return string(redacted), nil
+}
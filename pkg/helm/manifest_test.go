@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+var _ = Describe("Manifest", func() {
+
+	It("returns images used by containers and init containers", func() {
+		manifest := `---
+# Source: rasa-x/templates/rasa-x-deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: rasa-x
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: init-db
+          image: "bitnami/postgresql:11.9.0"
+      containers:
+        - name: rasa-x
+          image: "rasa/rasa-x:0.42.6"
+---
+# Source: rasa-x/templates/nginx-deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+        - name: nginx
+          image: "rasa/nginx:0.42.6"
+        - name: rasa-x
+          image: "rasa/rasa-x:0.42.6"
+---
+# Source: rasa-x/templates/rasa-x-configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rasa-x
+data:
+  image: "not-an-image"
+`
+		images, err := helm.ManifestImages(manifest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(images).To(Equal([]string{
+			"bitnami/postgresql:11.9.0",
+			"rasa/nginx:0.42.6",
+			"rasa/rasa-x:0.42.6",
+		}))
+	})
+
+	It("redacts data of secrets", func() {
+		manifest := `---
+# Source: rasa-x/templates/rasa-x-secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: rasa-x
+data:
+  rasaToken: dG9rZW4=
+stringData:
+  password: password
+---
+# Source: rasa-x/templates/rasa-x-configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rasa-x
+data:
+  key: value
+`
+		redacted, err := helm.RedactManifest(manifest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redacted).NotTo(ContainSubstring("dG9rZW4="))
+		Expect(redacted).NotTo(ContainSubstring("password: password"))
+		Expect(redacted).To(ContainSubstring("rasaToken: " + utils.RedactedValue))
+		Expect(redacted).To(ContainSubstring("key: value"))
+	})
+})
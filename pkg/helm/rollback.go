@@ -0,0 +1,47 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package helm
+
+import (
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/action"
+)
+
+// Rollback rolls back the helm release to a given revision.
+// If the revision is 0, the release is rolled back to the previous revision.
+func (h *Helm) Rollback(revision int) error {
+	client := action.NewRollback(h.ActionConfig)
+	client.Version = revision
+	client.Wait = true
+	client.Timeout = h.Configuration.Timeout
+	client.MaxHistory = 10
+
+	h.Log.V(1).Info("Helm client settings", "settings", client)
+
+	if err := client.Run(h.Configuration.ReleaseName); err != nil {
+		return err
+	}
+
+	msg := "Rollback has been finished"
+	if revision != 0 {
+		msg = fmt.Sprintf("Rollback to revision %d has been finished", revision)
+	}
+	h.Log.Info(msg, "releaseName", h.Configuration.ReleaseName, "namespace", h.Namespace)
+	h.Spinner.Message(msg)
+
+	return nil
+}
@@ -25,18 +25,7 @@ import (
 // Upgrade prepares and executes the upgrade.
 func (h *Helm) Upgrade() error {
 
-	err := h.updateRepository()
-	if err != nil {
-		return err
-	}
-
-	co := action.ChartPathOptions{
-		InsecureSkipTLSverify: false,
-		RepoURL:               h.Repositories[0].URL,
-		Version:               h.Configuration.Version,
-	}
-
-	chartPath, err := co.LocateChart(h.RasaXChartName, h.Settings)
+	chartPath, err := h.locateChart()
 	if err != nil {
 		return err
 	}
@@ -45,7 +34,8 @@ func (h *Helm) Upgrade() error {
 	client := action.NewUpgrade(h.ActionConfig)
 	client.Namespace = h.Namespace
 	client.Description = "rasactl"
-	client.Wait = true
+	client.Wait = !h.Configuration.DryRun
+	client.DryRun = h.Configuration.DryRun
 	client.Timeout = h.Configuration.Timeout
 	client.Atomic = h.Configuration.Atomic
 	client.ReuseValues = h.Configuration.ReuseValues
@@ -81,11 +71,15 @@ func (h *Helm) Upgrade() error {
 		return err
 	}
 	h.setCacheDirectory(cachePath)
+	h.Release = rel
 
 	var msg string
-	if !h.Configuration.StartProject {
+	switch {
+	case client.DryRun:
+		msg = "Dry run has been finished, no changes have been applied"
+	case !h.Configuration.StartProject:
 		msg = fmt.Sprintf("Upgrade has beed finished, status: %s", rel.Info.Status)
-	} else {
+	default:
 		msg = fmt.Sprintf("Rasa X for the %s deployment is ready", h.Namespace)
 	}
 	h.Log.Info(msg, "releaseName", rel.Name, "namespace", client.Namespace)
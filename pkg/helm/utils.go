@@ -23,11 +23,15 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"golang.org/x/xerrors"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"sigs.k8s.io/yaml"
@@ -91,6 +95,25 @@ func (h *Helm) GetAllValues() (map[string]interface{}, error) {
 	return values, err
 }
 
+// GetUserValues returns values supplied by the user for the active helm release, without defaults of the helm chart.
+func (h *Helm) GetUserValues() (map[string]interface{}, error) {
+	client := action.NewGetValues(h.ActionConfig)
+
+	if h.Configuration == nil {
+		return nil, xerrors.Errorf("helm client requires to define a release name: %#v", h)
+	}
+
+	values, err := client.Run(h.Configuration.ReleaseName)
+	if err != nil {
+		return nil, err
+	}
+	h.Log.V(1).Info("Getting user values",
+		"releaseName", h.Configuration.ReleaseName,
+		"namespace", h.Namespace,
+	)
+	return values, nil
+}
+
 // GetValues returns values used by the client.
 func (h *Helm) GetValues() map[string]interface{} {
 	return h.Values
@@ -101,6 +124,51 @@ func (h *Helm) SetValues(values map[string]interface{}) {
 	h.Values = values
 }
 
+// GetManifest returns a manifest rendered by the last install or upgrade.
+func (h *Helm) GetManifest() string {
+	if h.Release == nil {
+		return ""
+	}
+	return h.Release.Manifest
+}
+
+// GetImages returns container images used by the manifest and hooks
+// rendered by the last install or upgrade.
+func (h *Helm) GetImages() ([]string, error) {
+	if h.Release == nil {
+		return nil, xerrors.Errorf("there is no rendered helm release")
+	}
+
+	manifests := []string{h.Release.Manifest}
+	for _, hook := range h.Release.Hooks {
+		manifests = append(manifests, hook.Manifest)
+	}
+
+	return ManifestImages(strings.Join(manifests, "\n---\n"))
+}
+
+// SaveChart saves the rasa-x helm chart as a chart archive in a given directory.
+// It returns a path to the chart archive and a version of the chart.
+func (h *Helm) SaveChart(dir string) (string, string, error) {
+	chartPath, err := h.locateChart()
+	if err != nil {
+		return "", "", err
+	}
+
+	helmChart, err := loader.Load(chartPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	file, err := chartutil.Save(helmChart, dir)
+	if err != nil {
+		return "", "", err
+	}
+	h.Log.Info("Saving helm chart", "file", file, "version", helmChart.Metadata.Version)
+
+	return file, helmChart.Metadata.Version, nil
+}
+
 // IsDeployed checks if a given helm release is deployed.
 // Return 'true' if release is found.
 func (h *Helm) IsDeployed() (bool, error) {
@@ -168,3 +236,36 @@ func (h *Helm) SetKubernetesBackendType(backend types.KubernetesBackendType) {
 func (h *Helm) SetPersistanceVolumeClaimName(name string) {
 	h.PVCName = name
 }
+
+// SetUseDedicatedNode sets the Helm.UseDedicatedNode field.
+func (h *Helm) SetUseDedicatedNode(use bool) {
+	h.UseDedicatedNode = use
+}
+
+// SetUseSyncPod sets the Helm.UseSyncPod field.
+func (h *Helm) SetUseSyncPod(use bool) {
+	h.UseSyncPod = use
+}
+
+// valuesRasaXVersion returns a Rasa X version deployed with given values,
+// the app version of the helm chart is used if the values don't define an image tag.
+func valuesRasaXVersion(values map[string]interface{}, helmChart *chart.Chart) string {
+	if rasax, ok := values["rasax"].(map[string]interface{}); ok {
+		if tag, ok := rasax["tag"].(string); ok && tag != "" {
+			return tag
+		}
+	}
+
+	return helmChart.Metadata.AppVersion
+}
+
+// valuesRasaVersion returns a Rasa version defined in given values.
+func valuesRasaVersion(values map[string]interface{}) string {
+	if rasa, ok := values["rasa"].(map[string]interface{}); ok {
+		if version, ok := rasa["version"].(string); ok {
+			return version
+		}
+	}
+
+	return ""
+}
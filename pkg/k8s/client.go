@@ -18,12 +18,16 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -41,9 +45,11 @@ type KubernetesInterface interface {
 	CreateNamespace() error
 	IsRasaXRunning() (bool, error)
 	GetPods() (*v1.PodList, error)
+	WatchPods(ctx context.Context) (watch.Interface, error)
 	DeleteRasaXPods() error
 	GetPostgreSQLSvcNodePort() (int32, error)
 	GetRasaXSvcNodePort() (int32, error)
+	GetRasaXService() (v1.Service, error)
 	GetRabbitMqSvcNodePort() (int32, error)
 	SaveSecretWithState(projectPath string) error
 	UpdateRasaXConfig(token string) error
@@ -54,9 +60,20 @@ type KubernetesInterface interface {
 	DeleteSecretWithState() error
 	GetPostgreSQLCreds() (string, string, error)
 	GetRabbitMqCreds() (string, string, error)
+	GetRedisPassword() (string, error)
 	IsNamespaceExist(namespace string) (bool, error)
 	IsSecretWithStateExist() bool
-	GetKindControlPlaneNode() (v1.Node, error)
+	GetControlPlaneNode() (v1.Node, error)
+	GetIngressClasses() ([]string, error)
+	ApplyManifest(manifest io.Reader) error
+	GetDeployment(namespace, name string) (*appsv1.Deployment, error)
+	WaitForDeployment(namespace, name string, timeout time.Duration) error
+	GetNodes() (*v1.NodeList, error)
+	ConfigureCoreDNS(domain, serviceNamespace, serviceName string) error
+	IsCoreDNSConfigured(domain string) (bool, error)
+	DescribePod(pod string) (string, error)
+	GetEvents() (*v1.EventList, error)
+	WatchEvents(ctx context.Context) (watch.Interface, error)
 	IsNamespaceManageable() bool
 	AddNamespaceLabel() error
 	DeleteNamespaceLabel() error
@@ -66,6 +83,11 @@ type KubernetesInterface interface {
 	PodStatus(conditions []v1.PodCondition) string
 	CreateVolume(hostPath string) (string, error)
 	DeleteVolume() error
+	CreateSyncVolume() (string, error)
+	CreateSyncPod() error
+	WaitForSyncPod(timeout time.Duration) error
+	DeleteSyncPod() error
+	PortForward(ctx context.Context, service string, port int32, localPort int) (uint16, error)
 	GetBackendType() types.KubernetesBackendType
 	SetNamespace(namespace string)
 	SetHelmValues(values map[string]interface{})
@@ -73,14 +95,21 @@ type KubernetesInterface interface {
 	GetCloudProvider() *cloud.Provider
 	LoadConfig() (*rest.Config, error)
 	GetLogs(pod string) *rest.Request
+	GetPodLogs(pod string, opts *v1.PodLogOptions) *rest.Request
 	GetPod(pod string) (*v1.Pod, error)
 	GetServiceWithLabels(opts metav1.ListOptions) (*v1.ServiceList, error)
+	Exec(pod, container string, command []string, stdin io.Reader, stdout, stderr io.Writer) error
+	DumpPostgreSQLDatabase(database string, w io.Writer) error
+	RestorePostgreSQLDatabase(database string, r io.Reader) error
+	GetPostgreSQLDatabases() []string
 }
 
 // Kubernetes represents Kubernetes client.
 type Kubernetes struct {
 	kubeconfig string
 
+	config *rest.Config
+
 	clientset *kubernetes.Clientset
 
 	// Namespace is a namepace name used by the client.
@@ -119,6 +148,7 @@ func New(client *Kubernetes) (KubernetesInterface, error) {
 	if err != nil {
 		return nil, err
 	}
+	client.config = config
 
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
@@ -369,6 +399,15 @@ func (k *Kubernetes) GetPods() (*v1.PodList, error) {
 	return pods, nil
 }
 
+// WatchPods watches pods for the active namespace until a given context is canceled.
+func (k *Kubernetes) WatchPods(ctx context.Context) (watch.Interface, error) {
+	labels := fmt.Sprintf("app.kubernetes.io/instance=%s", k.Helm.ReleaseName)
+
+	return k.clientset.CoreV1().Pods(k.Namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector: labels,
+	})
+}
+
 func (k *Kubernetes) DeleteRasaXPods() error {
 	labels := fmt.Sprintf("app.kubernetes.io/component=rasa-x,app.kubernetes.io/instance=%s", k.Helm.ReleaseName)
 
@@ -407,26 +446,35 @@ func (k *Kubernetes) GetRasaXSvcNodePort() (int32, error) {
 
 	k.Log.V(1).Info("Getting a node port for the Rasa X service")
 
+	svc, err := k.GetRasaXService()
+	if err != nil {
+		return 0, err
+	}
+
+	return svc.Spec.Ports[0].NodePort, nil
+}
+
+// GetRasaXService returns the Rasa X service.
+func (k *Kubernetes) GetRasaXService() (v1.Service, error) {
 	// If a release name is different than "rasa-x" then a service name has
 	// a different pattern (<release-name>-rasa-x-rasa-x).
 	// Use labels to be sure that the correct service is read.
 	lables := fmt.Sprintf("app.kubernetes.io/component=rasa-x,app.kubernetes.io/instance=%s",
 		k.Helm.ReleaseName)
 
-	svcs, err := k.clientset.CoreV1().Services(k.Namespace).List(context.TODO(), metav1.ListOptions{
+	svcs, err := k.GetServiceWithLabels(metav1.ListOptions{
 		LabelSelector: lables,
 		Limit:         1,
 	})
 	if err != nil {
-		return 0, err
+		return v1.Service{}, err
 	}
 
-	svc, err := k.clientset.CoreV1().Services(k.Namespace).Get(context.TODO(), svcs.Items[0].Name, metav1.GetOptions{})
-	if err != nil {
-		return 0, err
+	if len(svcs.Items) == 0 {
+		return v1.Service{}, xerrors.Errorf("can't find the Rasa X service for the %s release", k.Helm.ReleaseName)
 	}
 
-	return svc.Spec.Ports[0].NodePort, nil
+	return svcs.Items[0], nil
 }
 
 // GetRabbitMqNodePort returns a node port for the rabbitmq service.
@@ -456,21 +504,32 @@ func (k *Kubernetes) GetRabbitMqSvcNodePort() (int32, error) {
 func (k *Kubernetes) GetLogs(pod string) *rest.Request {
 
 	opts := v1.PodLogOptions{
-		Previous: k.Flags.Logs.Previous,
-		Follow:   k.Flags.Logs.Follow,
+		Previous:   k.Flags.Logs.Previous,
+		Follow:     k.Flags.Logs.Follow,
+		Timestamps: k.Flags.Logs.Timestamps,
 	}
 
 	if k.Flags.Logs.TailLines > 0 {
 		opts.TailLines = &k.Flags.Logs.TailLines
 	}
 
+	if k.Flags.Logs.Since > 0 {
+		sinceSeconds := int64(k.Flags.Logs.Since.Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+
 	if k.Flags.Logs.Container != "" {
 		opts.Container = k.Flags.Logs.Container
 	}
 
+	return k.GetPodLogs(pod, &opts)
+}
+
+// GetPodLogs returns the logs stream for a pod with given options.
+func (k *Kubernetes) GetPodLogs(pod string, opts *v1.PodLogOptions) *rest.Request {
 	return k.clientset.CoreV1().
 		Pods(k.Namespace).
-		GetLogs(pod, &opts)
+		GetLogs(pod, opts)
 }
 
 // GetPod returns a Pod object for a given pod.
@@ -0,0 +1,160 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	coreDNSNamespace  = "kube-system"
+	coreDNSConfigMap  = "coredns"
+	coreDNSDeployment = "coredns"
+)
+
+// ApplyManifest creates Kubernetes objects defined in a given manifest.
+// Objects that already exist are left unchanged.
+func (k *Kubernetes) ApplyManifest(manifest io.Reader) error {
+	kubeContext := viper.GetString("kube-context")
+	configFlags := &genericclioptions.ConfigFlags{
+		KubeConfig: &k.kubeconfig,
+		Context:    &kubeContext,
+	}
+
+	result := resource.NewBuilder(configFlags).
+		Unstructured().
+		Stream(manifest, "manifest").
+		Flatten().
+		Do()
+
+	return result.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+
+		k.Log.V(1).Info("Creating an object", "kind", info.Mapping.GroupVersionKind.Kind,
+			"name", info.Name, "namespace", info.Namespace)
+		_, err = resource.NewHelper(info.Client, info.Mapping).Create(info.Namespace, true, info.Object)
+		if errors.IsAlreadyExists(err) {
+			k.Log.V(1).Info("Object already exists", "kind", info.Mapping.GroupVersionKind.Kind, "name", info.Name)
+			return nil
+		}
+		return err
+	})
+}
+
+// GetDeployment returns a deployment object.
+func (k *Kubernetes) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+	return k.clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// WaitForDeployment waits until all replicas of a deployment are available.
+func (k *Kubernetes) WaitForDeployment(namespace, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		deployment, err := k.GetDeployment(namespace, name)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		if err == nil && deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.AvailableReplicas != 0 &&
+			deployment.Status.AvailableReplicas == deployment.Status.Replicas {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return xerrors.Errorf("timed out waiting for the %s/%s deployment to be ready", namespace, name)
+		}
+
+		k.Log.V(1).Info("Waiting for deployment", "namespace", namespace, "name", name)
+		time.Sleep(time.Second * 3)
+	}
+}
+
+// GetNodes returns all nodes of the cluster.
+func (k *Kubernetes) GetNodes() (*v1.NodeList, error) {
+	return k.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+}
+
+// ConfigureCoreDNS adds a zone for a given domain to the CoreDNS configuration. All names in the domain
+// resolve to the cluster IP address of a given service, e.g. the ingress controller, so that
+// pods can reach deployments using the same URLs as the host.
+func (k *Kubernetes) ConfigureCoreDNS(domain, serviceNamespace, serviceName string) error {
+	configured, err := k.IsCoreDNSConfigured(domain)
+	if err != nil || configured {
+		return err
+	}
+
+	service, err := k.clientset.CoreV1().Services(serviceNamespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	configMap, err := k.clientset.CoreV1().ConfigMaps(coreDNSNamespace).Get(context.TODO(), coreDNSConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	configMap.Data["Corefile"] = fmt.Sprintf(`%s
+%s:53 {
+    errors
+    template IN A {
+        answer "{{ .Name }} 60 IN A %s"
+    }
+    template ANY ANY {
+        rcode NOERROR
+    }
+}
+`, strings.TrimRight(configMap.Data["Corefile"], "\n"), domain, service.Spec.ClusterIP)
+
+	k.Log.Info("Updating the CoreDNS configuration", "domain", domain, "address", service.Spec.ClusterIP)
+	if _, err := k.clientset.CoreV1().ConfigMaps(coreDNSNamespace).Update(context.TODO(), configMap, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	// Restart CoreDNS to apply the configuration without waiting for the reload plugin.
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`,
+		time.Now().Format(time.RFC3339))
+	_, err = k.clientset.AppsV1().Deployments(coreDNSNamespace).Patch(context.TODO(), coreDNSDeployment,
+		types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+
+	return err
+}
+
+// IsCoreDNSConfigured checks if the CoreDNS configuration includes a zone for a given domain.
+func (k *Kubernetes) IsCoreDNSConfigured(domain string) (bool, error) {
+	configMap, err := k.clientset.CoreV1().ConfigMaps(coreDNSNamespace).Get(context.TODO(), coreDNSConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	return strings.Contains(configMap.Data["Corefile"], fmt.Sprintf("%s:53 {", domain)), nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/kubectl/pkg/describe"
+)
+
+// DescribePod returns a description of a given pod in the same format as 'kubectl describe pod'.
+func (k *Kubernetes) DescribePod(pod string) (string, error) {
+	describer := &describe.PodDescriber{Interface: k.clientset}
+	return describer.Describe(k.Namespace, pod, describe.DescriberSettings{ShowEvents: true, ChunkSize: 500})
+}
+
+// GetEvents returns a list of events for the active namespace.
+func (k *Kubernetes) GetEvents() (*v1.EventList, error) {
+	return k.clientset.CoreV1().Events(k.Namespace).List(context.TODO(), metav1.ListOptions{})
+}
+
+// WatchEvents watches events for the active namespace until a given context is canceled.
+func (k *Kubernetes) WatchEvents(ctx context.Context) (watch.Interface, error) {
+	return k.clientset.CoreV1().Events(k.Namespace).Watch(ctx, metav1.ListOptions{})
+}
@@ -5,11 +5,16 @@
 package fake
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	v1 "k8s.io/api/core/v1"
-	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/api/apps/v1"
+	v10 "k8s.io/api/core/v1"
+	v11 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	watch "k8s.io/apimachinery/pkg/watch"
 	rest "k8s.io/client-go/rest"
 
 	types "github.com/RasaHQ/rasactl/pkg/types"
@@ -53,6 +58,34 @@ func (mr *MockKubernetesInterfaceMockRecorder) AddNamespaceLabel() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNamespaceLabel", reflect.TypeOf((*MockKubernetesInterface)(nil).AddNamespaceLabel))
 }
 
+// ApplyManifest mocks base method.
+func (m *MockKubernetesInterface) ApplyManifest(arg0 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyManifest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyManifest indicates an expected call of ApplyManifest.
+func (mr *MockKubernetesInterfaceMockRecorder) ApplyManifest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyManifest", reflect.TypeOf((*MockKubernetesInterface)(nil).ApplyManifest), arg0)
+}
+
+// ConfigureCoreDNS mocks base method.
+func (m *MockKubernetesInterface) ConfigureCoreDNS(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureCoreDNS", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureCoreDNS indicates an expected call of ConfigureCoreDNS.
+func (mr *MockKubernetesInterfaceMockRecorder) ConfigureCoreDNS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureCoreDNS", reflect.TypeOf((*MockKubernetesInterface)(nil).ConfigureCoreDNS), arg0, arg1, arg2)
+}
+
 // CreateNamespace mocks base method.
 func (m *MockKubernetesInterface) CreateNamespace() error {
 	m.ctrl.T.Helper()
@@ -67,6 +100,35 @@ func (mr *MockKubernetesInterfaceMockRecorder) CreateNamespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNamespace", reflect.TypeOf((*MockKubernetesInterface)(nil).CreateNamespace))
 }
 
+// CreateSyncPod mocks base method.
+func (m *MockKubernetesInterface) CreateSyncPod() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSyncPod")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSyncPod indicates an expected call of CreateSyncPod.
+func (mr *MockKubernetesInterfaceMockRecorder) CreateSyncPod() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncPod", reflect.TypeOf((*MockKubernetesInterface)(nil).CreateSyncPod))
+}
+
+// CreateSyncVolume mocks base method.
+func (m *MockKubernetesInterface) CreateSyncVolume() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSyncVolume")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSyncVolume indicates an expected call of CreateSyncVolume.
+func (mr *MockKubernetesInterfaceMockRecorder) CreateSyncVolume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSyncVolume", reflect.TypeOf((*MockKubernetesInterface)(nil).CreateSyncVolume))
+}
+
 // CreateVolume mocks base method.
 func (m *MockKubernetesInterface) CreateVolume(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +214,20 @@ func (mr *MockKubernetesInterfaceMockRecorder) DeleteSecretWithState() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecretWithState", reflect.TypeOf((*MockKubernetesInterface)(nil).DeleteSecretWithState))
 }
 
+// DeleteSyncPod mocks base method.
+func (m *MockKubernetesInterface) DeleteSyncPod() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSyncPod")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSyncPod indicates an expected call of DeleteSyncPod.
+func (mr *MockKubernetesInterfaceMockRecorder) DeleteSyncPod() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSyncPod", reflect.TypeOf((*MockKubernetesInterface)(nil).DeleteSyncPod))
+}
+
 // DeleteVolume mocks base method.
 func (m *MockKubernetesInterface) DeleteVolume() error {
 	m.ctrl.T.Helper()
@@ -166,6 +242,49 @@ func (mr *MockKubernetesInterfaceMockRecorder) DeleteVolume() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVolume", reflect.TypeOf((*MockKubernetesInterface)(nil).DeleteVolume))
 }
 
+// DescribePod mocks base method.
+func (m *MockKubernetesInterface) DescribePod(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribePod", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribePod indicates an expected call of DescribePod.
+func (mr *MockKubernetesInterfaceMockRecorder) DescribePod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribePod", reflect.TypeOf((*MockKubernetesInterface)(nil).DescribePod), arg0)
+}
+
+// DumpPostgreSQLDatabase mocks base method.
+func (m *MockKubernetesInterface) DumpPostgreSQLDatabase(arg0 string, arg1 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpPostgreSQLDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DumpPostgreSQLDatabase indicates an expected call of DumpPostgreSQLDatabase.
+func (mr *MockKubernetesInterfaceMockRecorder) DumpPostgreSQLDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpPostgreSQLDatabase", reflect.TypeOf((*MockKubernetesInterface)(nil).DumpPostgreSQLDatabase), arg0, arg1)
+}
+
+// Exec mocks base method.
+func (m *MockKubernetesInterface) Exec(arg0, arg1 string, arg2 []string, arg3 io.Reader, arg4, arg5 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exec", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockKubernetesInterfaceMockRecorder) Exec(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockKubernetesInterface)(nil).Exec), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetBackendType mocks base method.
 func (m *MockKubernetesInterface) GetBackendType() types.KubernetesBackendType {
 	m.ctrl.T.Helper()
@@ -194,19 +313,64 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetCloudProvider() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudProvider", reflect.TypeOf((*MockKubernetesInterface)(nil).GetCloudProvider))
 }
 
-// GetKindControlPlaneNode mocks base method.
-func (m *MockKubernetesInterface) GetKindControlPlaneNode() (v1.Node, error) {
+// GetControlPlaneNode mocks base method.
+func (m *MockKubernetesInterface) GetControlPlaneNode() (v10.Node, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKindControlPlaneNode")
-	ret0, _ := ret[0].(v1.Node)
+	ret := m.ctrl.Call(m, "GetControlPlaneNode")
+	ret0, _ := ret[0].(v10.Node)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetKindControlPlaneNode indicates an expected call of GetKindControlPlaneNode.
-func (mr *MockKubernetesInterfaceMockRecorder) GetKindControlPlaneNode() *gomock.Call {
+// GetControlPlaneNode indicates an expected call of GetControlPlaneNode.
+func (mr *MockKubernetesInterfaceMockRecorder) GetControlPlaneNode() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKindControlPlaneNode", reflect.TypeOf((*MockKubernetesInterface)(nil).GetKindControlPlaneNode))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlPlaneNode", reflect.TypeOf((*MockKubernetesInterface)(nil).GetControlPlaneNode))
+}
+
+// GetDeployment mocks base method.
+func (m *MockKubernetesInterface) GetDeployment(arg0, arg1 string) (*v1.Deployment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeployment", arg0, arg1)
+	ret0, _ := ret[0].(*v1.Deployment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeployment indicates an expected call of GetDeployment.
+func (mr *MockKubernetesInterfaceMockRecorder) GetDeployment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockKubernetesInterface)(nil).GetDeployment), arg0, arg1)
+}
+
+// GetEvents mocks base method.
+func (m *MockKubernetesInterface) GetEvents() (*v10.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents")
+	ret0, _ := ret[0].(*v10.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockKubernetesInterfaceMockRecorder) GetEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockKubernetesInterface)(nil).GetEvents))
+}
+
+// GetIngressClasses mocks base method.
+func (m *MockKubernetesInterface) GetIngressClasses() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressClasses")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngressClasses indicates an expected call of GetIngressClasses.
+func (mr *MockKubernetesInterfaceMockRecorder) GetIngressClasses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressClasses", reflect.TypeOf((*MockKubernetesInterface)(nil).GetIngressClasses))
 }
 
 // GetLogs mocks base method.
@@ -238,11 +402,26 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetNamespaces() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaces", reflect.TypeOf((*MockKubernetesInterface)(nil).GetNamespaces))
 }
 
+// GetNodes mocks base method.
+func (m *MockKubernetesInterface) GetNodes() (*v10.NodeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodes")
+	ret0, _ := ret[0].(*v10.NodeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodes indicates an expected call of GetNodes.
+func (mr *MockKubernetesInterfaceMockRecorder) GetNodes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodes", reflect.TypeOf((*MockKubernetesInterface)(nil).GetNodes))
+}
+
 // GetPod mocks base method.
-func (m *MockKubernetesInterface) GetPod(arg0 string) (*v1.Pod, error) {
+func (m *MockKubernetesInterface) GetPod(arg0 string) (*v10.Pod, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPod", arg0)
-	ret0, _ := ret[0].(*v1.Pod)
+	ret0, _ := ret[0].(*v10.Pod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -253,11 +432,25 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetPod(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPod", reflect.TypeOf((*MockKubernetesInterface)(nil).GetPod), arg0)
 }
 
+// GetPodLogs mocks base method.
+func (m *MockKubernetesInterface) GetPodLogs(arg0 string, arg1 *v10.PodLogOptions) *rest.Request {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPodLogs", arg0, arg1)
+	ret0, _ := ret[0].(*rest.Request)
+	return ret0
+}
+
+// GetPodLogs indicates an expected call of GetPodLogs.
+func (mr *MockKubernetesInterfaceMockRecorder) GetPodLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodLogs", reflect.TypeOf((*MockKubernetesInterface)(nil).GetPodLogs), arg0, arg1)
+}
+
 // GetPods mocks base method.
-func (m *MockKubernetesInterface) GetPods() (*v1.PodList, error) {
+func (m *MockKubernetesInterface) GetPods() (*v10.PodList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPods")
-	ret0, _ := ret[0].(*v1.PodList)
+	ret0, _ := ret[0].(*v10.PodList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -284,6 +477,20 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetPostgreSQLCreds() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgreSQLCreds", reflect.TypeOf((*MockKubernetesInterface)(nil).GetPostgreSQLCreds))
 }
 
+// GetPostgreSQLDatabases mocks base method.
+func (m *MockKubernetesInterface) GetPostgreSQLDatabases() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostgreSQLDatabases")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetPostgreSQLDatabases indicates an expected call of GetPostgreSQLDatabases.
+func (mr *MockKubernetesInterfaceMockRecorder) GetPostgreSQLDatabases() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostgreSQLDatabases", reflect.TypeOf((*MockKubernetesInterface)(nil).GetPostgreSQLDatabases))
+}
+
 // GetPostgreSQLSvcNodePort mocks base method.
 func (m *MockKubernetesInterface) GetPostgreSQLSvcNodePort() (int32, error) {
 	m.ctrl.T.Helper()
@@ -330,6 +537,21 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetRabbitMqSvcNodePort() *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRabbitMqSvcNodePort", reflect.TypeOf((*MockKubernetesInterface)(nil).GetRabbitMqSvcNodePort))
 }
 
+// GetRasaXService mocks base method.
+func (m *MockKubernetesInterface) GetRasaXService() (v10.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRasaXService")
+	ret0, _ := ret[0].(v10.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRasaXService indicates an expected call of GetRasaXService.
+func (mr *MockKubernetesInterfaceMockRecorder) GetRasaXService() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRasaXService", reflect.TypeOf((*MockKubernetesInterface)(nil).GetRasaXService))
+}
+
 // GetRasaXSvcNodePort mocks base method.
 func (m *MockKubernetesInterface) GetRasaXSvcNodePort() (int32, error) {
 	m.ctrl.T.Helper()
@@ -375,11 +597,26 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetRasaXURL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRasaXURL", reflect.TypeOf((*MockKubernetesInterface)(nil).GetRasaXURL))
 }
 
+// GetRedisPassword mocks base method.
+func (m *MockKubernetesInterface) GetRedisPassword() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedisPassword")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedisPassword indicates an expected call of GetRedisPassword.
+func (mr *MockKubernetesInterfaceMockRecorder) GetRedisPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedisPassword", reflect.TypeOf((*MockKubernetesInterface)(nil).GetRedisPassword))
+}
+
 // GetServiceWithLabels mocks base method.
-func (m *MockKubernetesInterface) GetServiceWithLabels(arg0 v10.ListOptions) (*v1.ServiceList, error) {
+func (m *MockKubernetesInterface) GetServiceWithLabels(arg0 v11.ListOptions) (*v10.ServiceList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetServiceWithLabels", arg0)
-	ret0, _ := ret[0].(*v1.ServiceList)
+	ret0, _ := ret[0].(*v10.ServiceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -390,6 +627,21 @@ func (mr *MockKubernetesInterfaceMockRecorder) GetServiceWithLabels(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceWithLabels", reflect.TypeOf((*MockKubernetesInterface)(nil).GetServiceWithLabels), arg0)
 }
 
+// IsCoreDNSConfigured mocks base method.
+func (m *MockKubernetesInterface) IsCoreDNSConfigured(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCoreDNSConfigured", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCoreDNSConfigured indicates an expected call of IsCoreDNSConfigured.
+func (mr *MockKubernetesInterfaceMockRecorder) IsCoreDNSConfigured(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCoreDNSConfigured", reflect.TypeOf((*MockKubernetesInterface)(nil).IsCoreDNSConfigured), arg0)
+}
+
 // IsNamespaceExist mocks base method.
 func (m *MockKubernetesInterface) IsNamespaceExist(arg0 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -464,7 +716,7 @@ func (mr *MockKubernetesInterfaceMockRecorder) LoadConfig() *gomock.Call {
 }
 
 // PodStatus mocks base method.
-func (m *MockKubernetesInterface) PodStatus(arg0 []v1.PodCondition) string {
+func (m *MockKubernetesInterface) PodStatus(arg0 []v10.PodCondition) string {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PodStatus", arg0)
 	ret0, _ := ret[0].(string)
@@ -477,6 +729,21 @@ func (mr *MockKubernetesInterfaceMockRecorder) PodStatus(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodStatus", reflect.TypeOf((*MockKubernetesInterface)(nil).PodStatus), arg0)
 }
 
+// PortForward mocks base method.
+func (m *MockKubernetesInterface) PortForward(arg0 context.Context, arg1 string, arg2 int32, arg3 int) (uint16, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PortForward", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint16)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PortForward indicates an expected call of PortForward.
+func (mr *MockKubernetesInterfaceMockRecorder) PortForward(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortForward", reflect.TypeOf((*MockKubernetesInterface)(nil).PortForward), arg0, arg1, arg2, arg3)
+}
+
 // ReadSecretWithState mocks base method.
 func (m *MockKubernetesInterface) ReadSecretWithState() (map[string][]byte, error) {
 	m.ctrl.T.Helper()
@@ -492,6 +759,20 @@ func (mr *MockKubernetesInterfaceMockRecorder) ReadSecretWithState() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSecretWithState", reflect.TypeOf((*MockKubernetesInterface)(nil).ReadSecretWithState))
 }
 
+// RestorePostgreSQLDatabase mocks base method.
+func (m *MockKubernetesInterface) RestorePostgreSQLDatabase(arg0 string, arg1 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePostgreSQLDatabase", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestorePostgreSQLDatabase indicates an expected call of RestorePostgreSQLDatabase.
+func (mr *MockKubernetesInterfaceMockRecorder) RestorePostgreSQLDatabase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePostgreSQLDatabase", reflect.TypeOf((*MockKubernetesInterface)(nil).RestorePostgreSQLDatabase), arg0, arg1)
+}
+
 // SaveSecretWithState mocks base method.
 func (m *MockKubernetesInterface) SaveSecretWithState(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -601,3 +882,61 @@ func (mr *MockKubernetesInterfaceMockRecorder) UpdateSecretWithState(arg0 ...int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretWithState", reflect.TypeOf((*MockKubernetesInterface)(nil).UpdateSecretWithState), arg0...)
 }
+
+// WaitForDeployment mocks base method.
+func (m *MockKubernetesInterface) WaitForDeployment(arg0, arg1 string, arg2 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForDeployment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForDeployment indicates an expected call of WaitForDeployment.
+func (mr *MockKubernetesInterfaceMockRecorder) WaitForDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForDeployment", reflect.TypeOf((*MockKubernetesInterface)(nil).WaitForDeployment), arg0, arg1, arg2)
+}
+
+// WaitForSyncPod mocks base method.
+func (m *MockKubernetesInterface) WaitForSyncPod(arg0 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForSyncPod", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitForSyncPod indicates an expected call of WaitForSyncPod.
+func (mr *MockKubernetesInterfaceMockRecorder) WaitForSyncPod(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForSyncPod", reflect.TypeOf((*MockKubernetesInterface)(nil).WaitForSyncPod), arg0)
+}
+
+// WatchEvents mocks base method.
+func (m *MockKubernetesInterface) WatchEvents(arg0 context.Context) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEvents", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchEvents indicates an expected call of WatchEvents.
+func (mr *MockKubernetesInterfaceMockRecorder) WatchEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEvents", reflect.TypeOf((*MockKubernetesInterface)(nil).WatchEvents), arg0)
+}
+
+// WatchPods mocks base method.
+func (m *MockKubernetesInterface) WatchPods(arg0 context.Context) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchPods", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchPods indicates an expected call of WatchPods.
+func (mr *MockKubernetesInterfaceMockRecorder) WatchPods(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchPods", reflect.TypeOf((*MockKubernetesInterface)(nil).WatchPods), arg0)
+}
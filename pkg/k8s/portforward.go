@@ -0,0 +1,202 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// portForwardRetryInterval defines how long to wait before the port forwarding is restarted.
+const portForwardRetryInterval = 3 * time.Second
+
+// PortForward forwards a local port to a given port of a service, the first port of the service is used if the port is 0.
+// A random local port is used if localPort is 0.
+//
+// It returns after the port forwarding is ready, and the local port is forwarded until the context is done.
+// If the connection is lost, e.g. the pod is restarted, the port forwarding is restarted for a new pod.
+func (k *Kubernetes) PortForward(ctx context.Context, service string, port int32, localPort int) (uint16, error) {
+	local, done, err := k.forwardPort(ctx, service, port, localPort)
+	if err != nil {
+		return 0, err
+	}
+
+	go func() {
+		for {
+			err := <-done
+			if ctx.Err() != nil {
+				return
+			}
+			k.Log.Info("Port forwarding has been interrupted, restarting", "service", service, "error", err)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(portForwardRetryInterval):
+				}
+
+				_, done, err = k.forwardPort(ctx, service, port, int(local))
+				if err == nil {
+					break
+				}
+				k.Log.Info("Can't restart port forwarding", "service", service, "error", err)
+			}
+		}
+	}()
+
+	return local, nil
+}
+
+// forwardPort starts port forwarding to a pod of a given service. It returns the local port,
+// and a channel that receives a result of the port forwarding once it's finished.
+func (k *Kubernetes) forwardPort(ctx context.Context, service string, port int32, localPort int) (uint16, <-chan error, error) {
+	pod, podPort, err := k.getServicePod(service, port)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(k.config)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	url := k.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(k.Namespace).
+		Name(pod).
+		SubResource("portforward").
+		URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("%d:%d", localPort, podPort)}, stopCh, readyCh, ioutil.Discard, ioutil.Discard)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	k.Log.V(1).Info("Forwarding port", "service", service, "pod", pod, "port", podPort, "localPort", localPort)
+
+	done := make(chan error, 1)
+	finished := make(chan struct{})
+	go func() {
+		done <- forwarder.ForwardPorts()
+		close(finished)
+	}()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stopCh)
+		case <-finished:
+		}
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-done:
+		if err == nil {
+			err = xerrors.Errorf("port forwarding for the %s service has been stopped", service)
+		}
+		return 0, nil, err
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return ports[0].Local, done, nil
+}
+
+// getServicePod returns a name of a running pod that backs a given service port, and the pod port.
+func (k *Kubernetes) getServicePod(service string, port int32) (string, int, error) {
+	svc, err := k.clientset.CoreV1().Services(k.Namespace).Get(context.TODO(), service, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+
+	if len(svc.Spec.Ports) == 0 || len(svc.Spec.Selector) == 0 {
+		return "", 0, xerrors.Errorf("the %s service doesn't have ports or a selector", service)
+	}
+
+	svcPort := svc.Spec.Ports[0]
+	if port != 0 {
+		found := false
+		for _, p := range svc.Spec.Ports {
+			if p.Port == port {
+				svcPort = p
+				found = true
+			}
+		}
+		if !found {
+			return "", 0, xerrors.Errorf("the %s service doesn't have the %d port", service, port)
+		}
+	}
+
+	pods, err := k.clientset.CoreV1().Pods(k.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		podPort, err := podTargetPort(&pod, svcPort)
+		if err != nil {
+			return "", 0, err
+		}
+		return pod.Name, podPort, nil
+	}
+
+	return "", 0, xerrors.Errorf("there is no running pod for the %s service", service)
+}
+
+// podTargetPort returns a port of a given pod that is a target port for a given service port.
+func podTargetPort(pod *v1.Pod, svcPort v1.ServicePort) (int, error) {
+	switch {
+	case svcPort.TargetPort.Type == intstr.String:
+		for _, container := range pod.Spec.Containers {
+			for _, p := range container.Ports {
+				if p.Name == svcPort.TargetPort.StrVal {
+					return int(p.ContainerPort), nil
+				}
+			}
+		}
+		return 0, xerrors.Errorf("the %s pod doesn't have the %s port", pod.Name, svcPort.TargetPort.StrVal)
+	case svcPort.TargetPort.IntVal != 0:
+		return int(svcPort.TargetPort.IntVal), nil
+	default:
+		return int(svcPort.Port), nil
+	}
+}
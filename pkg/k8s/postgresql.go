@@ -0,0 +1,95 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"golang.org/x/xerrors"
+)
+
+// GetPostgreSQLDatabases returns names of the Rasa X and the tracker store databases.
+func (k *Kubernetes) GetPostgreSQLDatabases() []string {
+	rasaXDatabase := "rasa"
+	trackerDatabase := "tracker"
+
+	if global, ok := k.Helm.Values["global"].(map[string]interface{}); ok {
+		if postgresql, ok := global["postgresql"].(map[string]interface{}); ok {
+			if db, ok := postgresql["postgresqlDatabase"].(string); ok && db != "" {
+				rasaXDatabase = db
+			}
+		}
+	}
+
+	if rasa, ok := k.Helm.Values["rasa"].(map[string]interface{}); ok {
+		if db, ok := rasa["trackerDatabase"].(string); ok && db != "" {
+			trackerDatabase = db
+		}
+	}
+
+	return []string{rasaXDatabase, trackerDatabase}
+}
+
+// DumpPostgreSQLDatabase writes a SQL dump of a given database to w.
+// The dump is created with pg_dump executed in the postgresql pod.
+func (k *Kubernetes) DumpPostgreSQLDatabase(database string, w io.Writer) error {
+	username, password, err := k.GetPostgreSQLCreds()
+	if err != nil {
+		return err
+	}
+
+	stderr := new(bytes.Buffer)
+	command := []string{
+		"env", fmt.Sprintf("PGPASSWORD=%s", password),
+		"pg_dump", "--clean", "--if-exists", "--no-owner",
+		"-U", username, "-d", database,
+	}
+
+	k.Log.Info("Dumping PostgreSQL database", "database", database, "pod", k.getPostgreSQLPodName())
+	if err := k.Exec(k.getPostgreSQLPodName(), "", command, nil, w, stderr); err != nil {
+		return xerrors.Errorf("can't dump the %s database: %w, %s", database, err, stderr.String())
+	}
+
+	return nil
+}
+
+// RestorePostgreSQLDatabase restores a given database from a SQL dump read from r.
+func (k *Kubernetes) RestorePostgreSQLDatabase(database string, r io.Reader) error {
+	username, password, err := k.GetPostgreSQLCreds()
+	if err != nil {
+		return err
+	}
+
+	stderr := new(bytes.Buffer)
+	command := []string{
+		"env", fmt.Sprintf("PGPASSWORD=%s", password),
+		"psql", "--quiet", "-v", "ON_ERROR_STOP=1",
+		"-U", username, "-d", database,
+	}
+
+	k.Log.Info("Restoring PostgreSQL database", "database", database, "pod", k.getPostgreSQLPodName())
+	if err := k.Exec(k.getPostgreSQLPodName(), "", command, r, io.Discard, stderr); err != nil {
+		return xerrors.Errorf("can't restore the %s database: %w, %s", database, err, stderr.String())
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) getPostgreSQLPodName() string {
+	return fmt.Sprintf("%s-postgresql-0", k.Helm.ReleaseName)
+}
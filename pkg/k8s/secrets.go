@@ -17,6 +17,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"golang.org/x/xerrors"
@@ -50,6 +51,7 @@ func (k *Kubernetes) SaveSecretWithState(projectPath string) error {
 }
 
 // UpdateSecretWithState updates the rasactl secret.
+// Data stored as map[string][]byte is saved as it is, e.g. the state restored from a backup.
 func (k *Kubernetes) UpdateSecretWithState(data ...interface{}) error {
 	secret, err := k.clientset.CoreV1().Secrets(k.Namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
 	if err != nil {
@@ -73,6 +75,18 @@ func (k *Kubernetes) UpdateSecretWithState(data ...interface{}) error {
 			secret.Data[types.StateHelmReleaseName] = []byte(t.Name)
 			secret.Data[types.StateHelmReleaseStatus] = []byte(t.Info.Status)
 
+		case map[string][]byte:
+			for key, value := range t {
+				secret.Data[key] = value
+			}
+
+		case []rtypes.EnvironmentsEndpointRequest:
+			environments, err := json.Marshal(t)
+			if err != nil {
+				return err
+			}
+			secret.Data[types.StateEnvironments] = environments
+
 		default:
 			return xerrors.Errorf("can't update a secret with state, unknown data type: %T", d)
 		}
@@ -140,3 +154,14 @@ func (k *Kubernetes) GetRabbitMqCreds() (string, string, error) {
 
 	return username, string(secret.Data["rabbitmq-password"]), nil
 }
+
+// GetRedisPassword returns a password for the redis deployment.
+func (k *Kubernetes) GetRedisPassword() (string, error) {
+	secretName := fmt.Sprintf("%s-redis", k.Helm.ReleaseName)
+	secret, err := k.clientset.CoreV1().Secrets(k.Namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret.Data["redis-password"]), nil
+}
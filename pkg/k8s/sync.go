@@ -0,0 +1,162 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/xerrors"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	// SyncPodName is a name of the helper pod used to sync a local project into a persistent volume.
+	SyncPodName string = "rasactl-sync"
+
+	// SyncPodMountPath is a path where the project volume is mounted in the sync pod.
+	SyncPodMountPath string = "/project"
+
+	// syncPodUser is the user ID used by the rasa-x container, files are synced with this owner.
+	syncPodUser int64 = 1001
+
+	syncPodImage string = "busybox:1.34"
+)
+
+// CreateSyncVolume creates a persistent volume claim that uses the default storage class.
+// The volume is used to store a local project that is synced by the helper pod.
+func (k *Kubernetes) CreateSyncVolume() (string, error) {
+	pvcSpec := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volumeClaimName(k.Namespace),
+			Namespace: k.Namespace,
+			Labels: map[string]string{
+				"rasactl": "true",
+			},
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			AccessModes: []apiv1.PersistentVolumeAccessMode{apiv1.ReadWriteOnce},
+			Resources: apiv1.ResourceRequirements{
+				Requests: apiv1.ResourceList{
+					apiv1.ResourceStorage: resource.MustParse("2Gi"),
+				},
+			},
+		},
+	}
+
+	pvc, err := k.clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Create(context.TODO(), pvcSpec, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	k.Log.V(1).Info("Persistent Volume Claim has been created", "name", pvc.Name, "namespace", pvc.Namespace)
+	return pvc.Name, nil
+}
+
+// CreateSyncPod creates the helper pod that mounts the volume created by CreateSyncVolume.
+// Nothing is done if the pod already exists.
+func (k *Kubernetes) CreateSyncPod() error {
+	user := syncPodUser
+	gracePeriod := int64(0)
+	podSpec := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SyncPodName,
+			Namespace: k.Namespace,
+			Labels: map[string]string{
+				"rasactl":          "true",
+				types.SyncPodLabel: "true",
+			},
+		},
+		Spec: apiv1.PodSpec{
+			RestartPolicy:                 apiv1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: &gracePeriod,
+			SecurityContext: &apiv1.PodSecurityContext{
+				RunAsUser: &user,
+				FSGroup:   &user,
+			},
+			Containers: []apiv1.Container{
+				{
+					Name:    "sync",
+					Image:   syncPodImage,
+					Command: []string{"sh", "-c", "trap 'exit 0' TERM; while true; do sleep 1; done"},
+					VolumeMounts: []apiv1.VolumeMount{
+						{
+							Name:      "project",
+							MountPath: SyncPodMountPath,
+						},
+					},
+				},
+			},
+			Volumes: []apiv1.Volume{
+				{
+					Name: "project",
+					VolumeSource: apiv1.VolumeSource{
+						PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: volumeClaimName(k.Namespace),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k.clientset.CoreV1().Pods(k.Namespace).Create(context.TODO(), podSpec, metav1.CreateOptions{})
+	if err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	k.Log.V(1).Info("Sync pod has been created", "name", SyncPodName, "namespace", k.Namespace)
+	return nil
+}
+
+// WaitForSyncPod waits until the helper pod is running.
+func (k *Kubernetes) WaitForSyncPod(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		pod, err := k.GetPod(SyncPodName)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		if err == nil && pod.Status.Phase == apiv1.PodRunning {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return xerrors.Errorf("timed out waiting for the %s pod to be running", SyncPodName)
+		}
+
+		k.Log.V(1).Info("Waiting for pod", "namespace", k.Namespace, "name", SyncPodName)
+		time.Sleep(time.Second * 3)
+	}
+}
+
+// DeleteSyncPod deletes the helper pod.
+func (k *Kubernetes) DeleteSyncPod() error {
+	err := k.clientset.CoreV1().Pods(k.Namespace).Delete(context.TODO(), SyncPodName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	k.Log.V(1).Info("Sync pod has been deleted", "name", SyncPodName, "namespace", k.Namespace)
+	return nil
+}
@@ -71,10 +71,10 @@ func (k *Kubernetes) IsNamespaceExist(namespace string) (bool, error) {
 
 }
 
-// GetKindControlPlaneNode returns v1.Node object that defines a kind control plane node.
-func (k *Kubernetes) GetKindControlPlaneNode() (v1.Node, error) {
+// GetControlPlaneNode returns v1.Node object that defines a control plane node.
+func (k *Kubernetes) GetControlPlaneNode() (v1.Node, error) {
 
-	nodes, err := k.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/control-plane="})
+	nodes, err := k.clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/control-plane"})
 	if err != nil {
 		return v1.Node{}, err
 	}
@@ -208,3 +208,18 @@ func (k *Kubernetes) LoadConfig() (*rest.Config, error) {
 func (k *Kubernetes) GetServiceWithLabels(opts metav1.ListOptions) (*v1.ServiceList, error) {
 	return k.clientset.CoreV1().Services(k.Namespace).List(context.TODO(), opts)
 }
+
+// GetIngressClasses returns names of ingress classes available in the cluster.
+func (k *Kubernetes) GetIngressClasses() ([]string, error) {
+	ingressClasses, err := k.clientset.NetworkingV1().IngressClasses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, ingressClass := range ingressClasses.Items {
+		names = append(names, ingressClass.Name)
+	}
+
+	return names, nil
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -40,22 +41,44 @@ func (k *Kubernetes) CreateVolume(hostPath string) (string, error) {
 	return pvc.Name, nil
 }
 
-// DeleteVolumes deletes a volume that uses a local host path.
+// DeleteVolume deletes a volume that uses a local host path or a volume synced by the helper pod.
 func (k *Kubernetes) DeleteVolume() error {
-	pvc := fmt.Sprintf("rasactl-pvc-%s", k.Namespace)
-	if err := k.deletePVC(pvc); err != nil {
+	if err := k.deletePVC(volumeClaimName(k.Namespace)); err != nil {
 		return err
 	}
 
+	// A volume that is synced by the helper pod is dynamically provisioned, there is no persistent volume to delete.
 	pv := fmt.Sprintf("rasactl-pv-%s", k.Namespace)
-	err := k.deletePV(pv)
+	if err := k.deletePV(pv); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
 
-	return err
+	return nil
+}
+
+// hostPathStorageClassName is a storage class name used by a volume that uses a local host path.
+// The empty name disables dynamic provisioning, so that the claim is bound to the persistent volume
+// and a default storage class of a cluster, e.g. local-path in k3d, isn't set for the claim.
+const hostPathStorageClassName = ""
+
+func volumeClaimName(namespace string) string {
+	return fmt.Sprintf("rasactl-pvc-%s", namespace)
 }
 
 func (k *Kubernetes) createPV(hostPath string) (*apiv1.PersistentVolume, error) {
+	pv, err := k.clientset.CoreV1().PersistentVolumes().Create(context.TODO(), k.pvSpec(hostPath), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
 
-	pvSpec := &apiv1.PersistentVolume{
+	k.Log.V(1).Info("Persistent Volume has been created",
+		"name", pv.Name, "namespace", pv.Namespace, "hostPath", hostPath,
+	)
+	return pv, nil
+}
+
+func (k *Kubernetes) pvSpec(hostPath string) *apiv1.PersistentVolume {
+	return &apiv1.PersistentVolume{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("rasactl-pv-%s", k.Namespace),
 			Namespace: k.Namespace,
@@ -64,7 +87,7 @@ func (k *Kubernetes) createPV(hostPath string) (*apiv1.PersistentVolume, error)
 			},
 		},
 		Spec: apiv1.PersistentVolumeSpec{
-			StorageClassName: "standard",
+			StorageClassName: hostPathStorageClassName,
 			AccessModes:      []apiv1.PersistentVolumeAccessMode{"ReadWriteOnce"},
 			Capacity: apiv1.ResourceList{
 				apiv1.ResourceStorage: resource.MustParse("2Gi"),
@@ -76,30 +99,31 @@ func (k *Kubernetes) createPV(hostPath string) (*apiv1.PersistentVolume, error)
 			},
 		},
 	}
+}
 
-	pv, err := k.clientset.CoreV1().PersistentVolumes().Create(context.TODO(), pvSpec, metav1.CreateOptions{})
+func (k *Kubernetes) createPVC(pv *apiv1.PersistentVolume) (*apiv1.PersistentVolumeClaim, error) {
+	pvc, err := k.clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Create(context.TODO(), k.pvcSpec(pv), metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
-
-	k.Log.V(1).Info("Persistent Volume has been created",
-		"name", pv.Name, "namespace", pv.Namespace, "hostPath", hostPath,
-	)
-	return pv, nil
+	k.Log.V(1).Info("Persistent Volume Claim has been created", "name", pvc.Name, "namespace", pvc.Namespace)
+	return pvc, nil
 }
 
-func (k *Kubernetes) createPVC(pv *apiv1.PersistentVolume) (*apiv1.PersistentVolumeClaim, error) {
-
-	pvcSpec := &apiv1.PersistentVolumeClaim{
+func (k *Kubernetes) pvcSpec(pv *apiv1.PersistentVolume) *apiv1.PersistentVolumeClaim {
+	storageClassName := pv.Spec.StorageClassName
+	return &apiv1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("rasactl-pvc-%s", k.Namespace),
+			Name:      volumeClaimName(k.Namespace),
 			Namespace: k.Namespace,
 			Labels: map[string]string{
 				"rasactl": "true",
 			},
 		},
 		Spec: apiv1.PersistentVolumeClaimSpec{
-			AccessModes: []apiv1.PersistentVolumeAccessMode{"ReadWriteOnce"},
+			// The storage class has to match the class of the persistent volume, otherwise the claim is never bound.
+			StorageClassName: &storageClassName,
+			AccessModes:      []apiv1.PersistentVolumeAccessMode{"ReadWriteOnce"},
 			Resources: apiv1.ResourceRequirements{
 				Requests: apiv1.ResourceList{
 					apiv1.ResourceStorage: resource.MustParse(pv.Spec.Capacity.Storage().String()),
@@ -108,13 +132,6 @@ func (k *Kubernetes) createPVC(pv *apiv1.PersistentVolume) (*apiv1.PersistentVol
 			VolumeName: pv.Name,
 		},
 	}
-
-	pvc, err := k.clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Create(context.TODO(), pvcSpec, metav1.CreateOptions{})
-	if err != nil {
-		return nil, err
-	}
-	k.Log.V(1).Info("Persistent Volume Claim has been created", "name", pvc.Name, "namespace", pvc.Namespace)
-	return pvc, nil
 }
 
 func (k *Kubernetes) deletePV(name string) error {
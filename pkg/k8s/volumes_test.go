@@ -0,0 +1,36 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSpecsMatch(t *testing.T) {
+	k := &Kubernetes{Namespace: "test"}
+
+	pv := k.pvSpec("/tmp/project")
+	pvc := k.pvcSpec(pv)
+
+	require.NotNil(t, pvc.Spec.StorageClassName, "the claim has to set a storage class, otherwise a default class is used")
+	require.Equal(t, pv.Spec.StorageClassName, *pvc.Spec.StorageClassName)
+	require.Equal(t, pv.Name, pvc.Spec.VolumeName)
+	require.Equal(t, pv.Spec.AccessModes, pvc.Spec.AccessModes)
+	require.Equal(t, pv.Spec.Capacity.Storage().String(), pvc.Spec.Resources.Requests.Storage().String())
+	require.Equal(t, "/tmp/project", pv.Spec.HostPath.Path)
+}
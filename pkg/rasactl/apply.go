@@ -0,0 +1,341 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+// deploymentChange describes a change required to reconcile a deployment with its specification.
+type deploymentChange struct {
+	// description is a human readable description of the change.
+	description string
+
+	// diff stores a diff of the helm manifest if the change modifies the helm release.
+	diff string
+
+	apply func() error
+}
+
+// Apply reconciles a deployment with a given specification.
+//
+// Only changes detected by comparing the specification with the current state of the deployment are applied,
+// running the command again for the same specification doesn't change anything.
+func (r *RasaCtl) Apply(spec *types.DeploymentSpec) error {
+	changes, err := r.planDeploymentSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		r.Spinner.Stop()
+		fmt.Printf("No changes, the %s deployment matches the spec.\n", spec.Name)
+		return nil
+	}
+
+	for _, change := range changes {
+		r.Spinner.Message(fmt.Sprintf("Applying: %s", change.description))
+		r.Log.Info("Applying change", "deployment", spec.Name, "change", change.description)
+		if err := change.apply(); err != nil {
+			return xerrors.Errorf("can't %s: %w", change.description, err)
+		}
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The %s deployment matches the spec, applied changes: %d.\n", spec.Name, len(changes))
+	if r.IsProjectSynced() {
+		fmt.Printf("The project is synced with the %s deployment, use the 'rasactl sync' command to sync further changes.\n", spec.Name)
+	}
+	return nil
+}
+
+// Diff prints changes that the Apply method would apply for a given specification.
+func (r *RasaCtl) Diff(spec *types.DeploymentSpec) error {
+	changes, err := r.planDeploymentSpec(spec)
+	if err != nil {
+		return err
+	}
+	r.Spinner.Stop()
+
+	if len(changes) == 0 {
+		fmt.Printf("No changes, the %s deployment matches the spec.\n", spec.Name)
+		return nil
+	}
+
+	fmt.Printf("Changes for the %s deployment:\n", spec.Name)
+	for _, change := range changes {
+		fmt.Printf("  * %s\n", change.description)
+	}
+
+	for _, change := range changes {
+		if change.diff != "" {
+			fmt.Println()
+			fmt.Print(change.diff)
+		}
+	}
+
+	return nil
+}
+
+// planDeploymentSpec returns changes required to reconcile a deployment with a given specification.
+func (r *RasaCtl) planDeploymentSpec(spec *types.DeploymentSpec) ([]deploymentChange, error) {
+	changes := []deploymentChange{}
+
+	r.Spinner.Message("Comparing the deployment with the spec")
+	isDeployed, isRunning, err := r.CheckDeploymentStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	if isDeployed {
+		state, err := r.KubernetesClient.ReadSecretWithState()
+		if err != nil {
+			return nil, err
+		}
+
+		if projectPath := string(state[types.StateProjectPath]); projectPath != spec.ProjectPath {
+			return nil, xerrors.Errorf("the project path can't be changed for an existing deployment, current: %q, spec: %q",
+				projectPath, spec.ProjectPath)
+		}
+	}
+
+	// The project is used by the start action if the deployment is created.
+	r.Flags.Start.ProjectPath = spec.ProjectPath
+
+	if err := r.setDeploymentSpecValues(spec, isDeployed); err != nil {
+		return nil, err
+	}
+
+	if !isRunning {
+		// Render a stopped deployment the same way as the start action does.
+		helmConfig := r.HelmClient.GetConfiguration()
+		helmConfig.StartProject = isDeployed
+		r.HelmClient.SetConfiguration(helmConfig)
+	}
+
+	currentManifest, renderedManifest, err := r.renderManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := helm.ManifestDiff(currentManifest, renderedManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !isDeployed:
+		changes = append(changes, deploymentChange{description: "create the deployment", diff: diff, apply: r.Start})
+	case !isRunning:
+		changes = append(changes, deploymentChange{description: "start the deployment", diff: diff, apply: r.Start})
+	case diff != "":
+		changes = append(changes, deploymentChange{description: "upgrade the deployment", diff: diff, apply: r.Upgrade})
+	}
+
+	rasaXChanges, err := r.planDeploymentSpecRasaX(spec, isRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(changes, rasaXChanges...), nil
+}
+
+// planDeploymentSpecRasaX returns changes for the Rasa X configuration: the Enterprise license,
+// environments and models. If Rasa X is not running, all changes defined by the specification are returned.
+func (r *RasaCtl) planDeploymentSpecRasaX(spec *types.DeploymentSpec, isRunning bool) ([]deploymentChange, error) {
+	changes := []deploymentChange{}
+
+	if isRunning {
+		r.initRasaXClient()
+	}
+
+	if spec.Enterprise != nil {
+		isActive := false
+		if isRunning {
+			version, err := r.RasaXClient.GetVersionEndpoint()
+			if err != nil {
+				return nil, err
+			}
+			isActive = version.Enterprise
+		}
+
+		if !isActive {
+			enterprise := spec.Enterprise
+			changes = append(changes, deploymentChange{
+				description: "activate the Enterprise license",
+				apply: func() error {
+					return r.enterpriseActivate(func() (string, error) {
+						return utils.ReadDeploymentSpecLicense(enterprise)
+					})
+				},
+			})
+		}
+	}
+
+	if len(spec.Environments) != 0 {
+		environments, err := json.Marshal(spec.Environments)
+		if err != nil {
+			return nil, err
+		}
+
+		currentEnvironments := []byte{}
+		if isRunning {
+			state, err := r.KubernetesClient.ReadSecretWithState()
+			if err != nil {
+				return nil, err
+			}
+			currentEnvironments = state[types.StateEnvironments]
+		}
+
+		if !bytes.Equal(environments, currentEnvironments) {
+			names := []string{}
+			for _, environment := range spec.Environments {
+				names = append(names, environment.Name)
+			}
+
+			changes = append(changes, deploymentChange{
+				description: fmt.Sprintf("save environments: %s", strings.Join(names, ", ")),
+				apply: func() error {
+					return r.saveDeploymentSpecEnvironments(spec)
+				},
+			})
+		}
+	}
+
+	if len(spec.Models) != 0 {
+		modelChanges, err := r.planDeploymentSpecModels(spec, isRunning)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, modelChanges...)
+	}
+
+	return changes, nil
+}
+
+func (r *RasaCtl) planDeploymentSpecModels(spec *types.DeploymentSpec, isRunning bool) ([]deploymentChange, error) {
+	changes := []deploymentChange{}
+
+	// Tags of models that already exist in Rasa X, models are listed by Rasa X
+	// even if a Rasa server is not connected.
+	models := map[string]map[string]bool{}
+	if isRunning {
+		token, err := r.getAuthToken()
+		if err != nil {
+			return nil, err
+		}
+		r.RasaXClient.BearerToken = token
+
+		modelList, err := r.RasaXClient.ModelList()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, model := range modelList.Models {
+			models[model.Model] = map[string]bool{}
+			for _, tag := range model.Tags {
+				models[model.Model][tag] = true
+			}
+		}
+	}
+
+	for _, model := range spec.Models {
+		file := model.File
+		name := strings.TrimSuffix(filepath.Base(file), ".tar.gz")
+
+		currentTags, exists := models[name]
+		if !exists {
+			changes = append(changes, deploymentChange{
+				description: fmt.Sprintf("upload the %s model", name),
+				apply: func() error {
+					return r.ModelUpload(file, r.Flags.Model.Upload.Timeout)
+				},
+			})
+		}
+
+		for _, tag := range model.Tags {
+			if currentTags[tag] {
+				continue
+			}
+
+			tag := tag
+			changes = append(changes, deploymentChange{
+				description: fmt.Sprintf("tag the %s model as %s", name, tag),
+				apply: func() error {
+					return r.ModelTag(name, tag)
+				},
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// setDeploymentSpecValues reads the values file defined by the specification,
+// merges values with inline values and sets them for the helm client.
+//
+// Values of the current helm release are not reused, so that values removed from the specification
+// are removed from the deployment. Only values managed by rasactl are kept for a deployed deployment,
+// e.g. the mounted project or the Rasa X password.
+func (r *RasaCtl) setDeploymentSpecValues(spec *types.DeploymentSpec, isDeployed bool) error {
+	base := map[string]interface{}{}
+	if isDeployed {
+		values, err := r.HelmClient.GetUserValues()
+		if err != nil {
+			return err
+		}
+		base = helm.ManagedValues(values)
+	}
+
+	r.Flags.StartUpgrade.ValuesFile = spec.ValuesFile
+	r.HelmClient.SetValues(nil)
+
+	if err := r.HelmClient.ReadValuesFile(); err != nil {
+		return err
+	}
+	r.HelmClient.SetValues(utils.MergeMaps(base, r.HelmClient.GetValues(), spec.Values))
+
+	// Values are already read, make sure that the helm client doesn't read the values file again.
+	r.Flags.StartUpgrade.ValuesFile = ""
+
+	return nil
+}
+
+func (r *RasaCtl) saveDeploymentSpecEnvironments(spec *types.DeploymentSpec) error {
+	r.initRasaXClient()
+
+	token, err := r.getAuthToken()
+	if err != nil {
+		return err
+	}
+	r.RasaXClient.BearerToken = token
+
+	if err := r.RasaXClient.SaveEnvironments(spec.Environments); err != nil {
+		return err
+	}
+
+	return r.KubernetesClient.UpdateSecretWithState(spec.Environments)
+}
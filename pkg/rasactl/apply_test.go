@@ -0,0 +1,150 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/release"
+
+	fh "github.com/RasaHQ/rasactl/pkg/helm/fake"
+	fk "github.com/RasaHQ/rasactl/pkg/k8s/fake"
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+func TestPlanDeploymentSpecIsIdempotent(t *testing.T) {
+	// Rasa X with the model from the spec, a Rasa server is not connected.
+	rasaX := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/auth":
+			w.Write([]byte(`{"access_token": "token"}`)) //nolint:errcheck
+		case "/api/projects/default/models":
+			w.Write([]byte(`[{"model": "model", "tags": ["production"]}]`)) //nolint:errcheck
+		}
+	}))
+	defer rasaX.Close()
+
+	_, port, err := net.SplitHostPort(rasaX.Listener.Addr().String())
+	require.NoError(t, err)
+	rasaXPort, err := strconv.ParseUint(port, 10, 16)
+	require.NoError(t, err)
+
+	t.Setenv(types.RasaCtlAuthUserEnv, "me")
+	t.Setenv(types.RasaCtlAuthPasswordEnv, "password")
+
+	spec := &types.DeploymentSpec{
+		Name:        "test-deployment",
+		ProjectPath: "/tmp/project",
+		Models: []types.DeploymentModelSpec{
+			{File: "/tmp/model.tar.gz", Tags: []string{"production"}},
+		},
+	}
+	manifest := "kind: Deployment\n"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mk := fk.NewMockKubernetesInterface(ctrl)
+	mh := fh.NewMockInterface(ctrl)
+
+	mh.EXPECT().GetConfiguration().Return(&types.HelmConfigurationSpec{}).AnyTimes()
+	mh.EXPECT().SetConfiguration(gomock.Any()).AnyTimes()
+	mh.EXPECT().SetValues(gomock.Any()).AnyTimes()
+	mh.EXPECT().GetValues().AnyTimes()
+	mh.EXPECT().ReadValuesFile().AnyTimes()
+	mh.EXPECT().GetManifest().Return(manifest).AnyTimes()
+
+	r := &RasaCtl{
+		KubernetesClient: mk,
+		HelmClient:       mh,
+		Log:              logr.Discard(),
+		Spinner:          status.NewSpinner(),
+		Namespace:        spec.Name,
+		Flags:            &types.RasaCtlFlags{},
+		forwardedPorts:   &forwardedPorts{rasaX: uint16(rasaXPort)},
+	}
+	defer r.Spinner.Stop()
+
+	// The first apply creates the deployment with the project defined by the spec.
+	mh.EXPECT().IsDeployed().Return(false, nil)
+	mk.EXPECT().IsRasaXRunning().Return(false, nil)
+	mh.EXPECT().SetPersistanceVolumeClaimName(gomock.Any())
+	mh.EXPECT().Install()
+
+	changes, err := r.planDeploymentSpec(spec)
+	require.NoError(t, err)
+	require.NotEmpty(t, changes)
+	require.Equal(t, "create the deployment", changes[0].description)
+	require.Equal(t, spec.ProjectPath, r.Flags.Start.ProjectPath)
+
+	// The second apply doesn't change anything.
+	r.Flags.Start.ProjectPath = ""
+	mh.EXPECT().IsDeployed().Return(true, nil)
+	mk.EXPECT().IsRasaXRunning().Return(true, nil)
+	mk.EXPECT().ReadSecretWithState().Return(map[string][]byte{types.StateProjectPath: []byte(spec.ProjectPath)}, nil)
+	mh.EXPECT().GetUserValues().Return(map[string]interface{}{}, nil)
+	mh.EXPECT().GetStatus().Return(&release.Release{Manifest: manifest}, nil)
+	mh.EXPECT().Upgrade()
+
+	changes, err = r.planDeploymentSpec(spec)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestSetDeploymentSpecValuesKeepsOnlyManagedValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mh := fh.NewMockInterface(ctrl)
+
+	var values map[string]interface{}
+	mh.EXPECT().SetValues(gomock.Any()).Do(func(v map[string]interface{}) { values = v }).AnyTimes()
+	mh.EXPECT().GetValues().DoAndReturn(func() map[string]interface{} { return values }).AnyTimes()
+	mh.EXPECT().ReadValuesFile()
+	// The current release uses a value that has been removed from the spec.
+	mh.EXPECT().GetUserValues().Return(map[string]interface{}{
+		"rasax": map[string]interface{}{
+			"initialUser": map[string]interface{}{"password": "password"},
+			"tag":         "1.0.0",
+		},
+	}, nil)
+
+	r := &RasaCtl{
+		HelmClient: mh,
+		Log:        logr.Discard(),
+		Flags:      &types.RasaCtlFlags{},
+	}
+
+	spec := &types.DeploymentSpec{
+		Name:   "test-deployment",
+		Values: map[string]interface{}{"rasax": map[string]interface{}{"replicaCount": 2}},
+	}
+	require.NoError(t, r.setDeploymentSpecValues(spec, true))
+	require.Equal(t, map[string]interface{}{
+		"rasax": map[string]interface{}{
+			"initialUser":  map[string]interface{}{"password": "password"},
+			"replicaCount": 2,
+		},
+	}, values)
+}
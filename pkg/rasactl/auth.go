@@ -23,6 +23,7 @@ import (
 
 	"github.com/RasaHQ/rasactl/pkg/credentials"
 	"github.com/RasaHQ/rasactl/pkg/credentials/helpers"
+	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
 	"github.com/RasaHQ/rasactl/pkg/utils"
 )
@@ -172,3 +173,53 @@ func (r *RasaCtl) getCredsFromEnv() (string, string) {
 
 	return user, password
 }
+
+// AuthStatus prints authentication state for a deployment.
+func (r *RasaCtl) AuthStatus() error {
+	state := types.AuthStatusOutput{
+		Deployment: r.Namespace,
+		Source:     "none",
+	}
+
+	if user, password := r.getCredsFromEnv(); user != "" && password != "" {
+		state.LoggedIn = true
+		state.Source = "environment"
+		state.Username = user
+	} else {
+		credsStore := credentials.Credentials{
+			Namespace: r.Namespace,
+			Helper:    helpers.Helper,
+		}
+
+		r.Log.V(1).Info("Getting credentials from the store", "name", "rasactl-login", "namespace", r.Namespace)
+		user, password, err := credsStore.Get("rasactl-login")
+		if err != nil {
+			r.Log.V(1).Info("Can't get credentials from the store", "error", err)
+		} else if user != "" && password != "" {
+			state.LoggedIn = true
+			state.Source = "credentials-store"
+			state.Username = user
+		}
+	}
+
+	if !status.IsTableOutput(r.Flags.Auth.Status.Output) {
+		return status.FprintObject(os.Stdout, state, r.Flags.Auth.Status.Output)
+	}
+
+	loggedIn := "no"
+	if state.LoggedIn {
+		loggedIn = "yes"
+	}
+
+	d := [][]string{
+		{"Deployment:", state.Deployment},
+		{"Logged in:", loggedIn},
+		{"Source:", state.Source},
+	}
+	if state.Username != "" {
+		d = append(d, []string{"Username:", state.Username})
+	}
+	status.PrintTableNoHeader(d)
+
+	return nil
+}
@@ -0,0 +1,309 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+	"github.com/RasaHQ/rasactl/pkg/version"
+)
+
+// Backup creates a backup of a given deployment.
+//
+// The backup is a gzip-compressed tar archive that includes helm values,
+// the rasactl state, dumps of the PostgreSQL databases and models.
+func (r *RasaCtl) Backup() error {
+	msg := "Creating a backup"
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "namespace", r.Namespace, "file", r.Flags.Backup.File)
+
+	dir, err := ioutil.TempDir("", fmt.Sprintf("rasactl-backup-%s-", r.Namespace))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	state, err := r.KubernetesClient.ReadSecretWithState()
+	if err != nil {
+		return err
+	}
+
+	if err := r.GetAllHelmValues(); err != nil {
+		return err
+	}
+
+	metadata := types.BackupMetadata{
+		Deployment:       r.Namespace,
+		CreatedAt:        time.Now().UTC(),
+		RasaCtlVersion:   version.VERSION,
+		HelmChartVersion: string(state[types.StateHelmChartVersion]),
+		RasaXVersion:     string(state[types.StateRasaXVersion]),
+	}
+
+	r.Spinner.Message("Saving helm values and the deployment state")
+	if err := writeYAMLFile(filepath.Join(dir, types.BackupValuesFile), r.HelmClient.GetValues()); err != nil {
+		return err
+	}
+
+	stateData := map[string]string{}
+	for key, value := range state {
+		stateData[key] = string(value)
+	}
+	if err := writeYAMLFile(filepath.Join(dir, types.BackupStateFile), stateData); err != nil {
+		return err
+	}
+
+	dbDir := filepath.Join(dir, types.BackupDatabasesDir)
+	if err := os.Mkdir(dbDir, 0755); err != nil {
+		return err
+	}
+
+	for _, database := range r.KubernetesClient.GetPostgreSQLDatabases() {
+		r.Spinner.Message(fmt.Sprintf("Dumping the %s database", database))
+		f, err := os.Create(filepath.Join(dbDir, fmt.Sprintf("%s.sql", database)))
+		if err != nil {
+			return err
+		}
+		err = r.KubernetesClient.DumpPostgreSQLDatabase(database, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		metadata.Databases = append(metadata.Databases, database)
+	}
+
+	if !r.Flags.Backup.SkipModels {
+		if err := r.backupModels(filepath.Join(dir, types.BackupModelsDir), &metadata); err != nil {
+			return err
+		}
+	}
+
+	if err := writeYAMLFile(filepath.Join(dir, types.BackupMetadataFile), metadata); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Writing the backup archive")
+	if err := utils.CreateArchive(dir, r.Flags.Backup.File); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The backup of the %s deployment has been saved to %s\n", r.Namespace, r.Flags.Backup.File)
+	return nil
+}
+
+// Restore restores a given deployment from a backup created by the Backup method.
+func (r *RasaCtl) Restore() error {
+	msg := "Restoring a backup"
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "namespace", r.Namespace, "file", r.Flags.Restore.File)
+
+	dir, err := ioutil.TempDir("", fmt.Sprintf("rasactl-restore-%s-", r.Namespace))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := utils.ExtractArchive(r.Flags.Restore.File, dir); err != nil {
+		return err
+	}
+
+	metadata := types.BackupMetadata{}
+	if err := readYAMLFile(filepath.Join(dir, types.BackupMetadataFile), &metadata); err != nil {
+		return xerrors.Errorf("the %s file is not a valid rasactl backup: %w", r.Flags.Restore.File, err)
+	}
+
+	if !r.Flags.Restore.SkipValues {
+		values := map[string]interface{}{}
+		if err := readYAMLFile(filepath.Join(dir, types.BackupValuesFile), &values); err != nil {
+			return err
+		}
+
+		helmConfig := r.HelmClient.GetConfiguration()
+		switch {
+		case r.Flags.Restore.ChartVersion != "":
+			helmConfig.Version = r.Flags.Restore.ChartVersion
+		case metadata.HelmChartVersion != "":
+			r.Log.Info("Using the helm chart version stored in the backup",
+				"version", metadata.HelmChartVersion, "currentVersion", helmConfig.Version)
+			helmConfig.Version = metadata.HelmChartVersion
+		}
+		helmConfig.ReuseValues = false
+		r.HelmClient.SetConfiguration(helmConfig)
+		r.HelmClient.SetValues(values)
+
+		r.Spinner.Message("Restoring helm values")
+		if err := r.HelmClient.Upgrade(); err != nil {
+			return helm.ErrorTimeoutWaitForCondition(err)
+		}
+	}
+
+	if err := r.GetAllHelmValues(); err != nil {
+		return err
+	}
+
+	for _, database := range metadata.Databases {
+		r.Spinner.Message(fmt.Sprintf("Restoring the %s database", database))
+		f, err := os.Open(filepath.Join(dir, types.BackupDatabasesDir, fmt.Sprintf("%s.sql", database)))
+		if err != nil {
+			return err
+		}
+		err = r.KubernetesClient.RestorePostgreSQLDatabase(database, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("Restarting Rasa X pod")
+	if err := r.KubernetesClient.DeleteRasaXPods(); err != nil {
+		return err
+	}
+
+	r.initRasaXClient()
+	if err := r.RasaXClient.WaitForRasaX(); err != nil {
+		return err
+	}
+
+	if !r.Flags.Restore.SkipModels && len(metadata.Models) != 0 {
+		if err := r.restoreModels(filepath.Join(dir, types.BackupModelsDir), &metadata); err != nil {
+			return err
+		}
+	}
+
+	state, err := readBackupState(filepath.Join(dir, types.BackupStateFile))
+	if err != nil {
+		return err
+	}
+	if r.Flags.Restore.SkipValues {
+		// The project path is a part of the deployment configuration that isn't restored.
+		delete(state, types.StateProjectPath)
+	}
+
+	rasaXVersion, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		return err
+	}
+
+	helmRelease, err := r.HelmClient.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	// The state from the backup is saved first, versions and the helm release are updated with the current values.
+	if err := r.KubernetesClient.UpdateSecretWithState(state, rasaXVersion, helmRelease); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The %s deployment has been restored from %s\n", r.Namespace, r.Flags.Restore.File)
+	return nil
+}
+
+// readBackupState reads the deployment state saved by the Backup method.
+func readBackupState(file string) (map[string][]byte, error) {
+	stateData := map[string]string{}
+	if err := readYAMLFile(file, &stateData); err != nil {
+		return nil, err
+	}
+
+	state := map[string][]byte{}
+	for key, value := range stateData {
+		state[key] = []byte(value)
+	}
+
+	return state, nil
+}
+
+func (r *RasaCtl) backupModels(dir string, metadata *types.BackupMetadata) error {
+	r.initRasaXClient()
+
+	token, err := r.getAuthToken()
+	if err != nil {
+		return err
+	}
+	r.RasaXClient.BearerToken = token
+
+	models, err := r.RasaXClient.ModelList()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, model := range models.Models {
+		r.Flags.Model.Download.Name = model.Model
+		r.Flags.Model.Download.FilePath = filepath.Join(dir, fmt.Sprintf("%s.tar.gz", model.Model))
+
+		if err := r.RasaXClient.ModelDownload(); err != nil {
+			return err
+		}
+		metadata.Models = append(metadata.Models, model)
+	}
+
+	return nil
+}
+
+func (r *RasaCtl) restoreModels(dir string, metadata *types.BackupMetadata) error {
+	token, err := r.getAuthToken()
+	if err != nil {
+		return err
+	}
+	r.RasaXClient.BearerToken = token
+
+	for _, model := range metadata.Models {
+		file := filepath.Join(dir, fmt.Sprintf("%s.tar.gz", model.Model))
+		if err := r.RasaXClient.ModelUpload(file, r.Flags.Model.Upload.Timeout); err != nil {
+			return err
+		}
+
+		for _, tag := range model.Tags {
+			if err := r.RasaXClient.ModelTag(model.Model, tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeYAMLFile(file string, data interface{}) error {
+	content, err := yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0644)
+}
+
+func readYAMLFile(file string, data interface{}) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(content, data)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+	"github.com/RasaHQ/rasactl/pkg/version"
+)
+
+// BundleCreate creates an air-gapped bundle.
+//
+// The bundle is a gzip-compressed tar archive that includes the rasa-x helm chart
+// and all container images used by the rendered chart in the docker save format.
+func (r *RasaCtl) BundleCreate() error {
+	file := r.Flags.Bundle.Create.File
+	msg := "Creating a bundle"
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "file", file)
+
+	dir, err := ioutil.TempDir("", "rasactl-bundle-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	chartDir := filepath.Join(dir, types.BundleChartDir)
+	if err := os.Mkdir(chartDir, 0755); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Downloading the helm chart")
+	chartFile, chartVersion, err := r.HelmClient.SaveChart(chartDir)
+	if err != nil {
+		return err
+	}
+
+	// Render the saved chart without access to the cluster to get a list of images.
+	r.Flags.StartUpgrade.Chart = chartFile
+	helmConfig := r.HelmClient.GetConfiguration()
+	helmConfig.Version = chartVersion
+	helmConfig.DryRun = true
+	helmConfig.ClientOnly = true
+	r.HelmClient.SetConfiguration(helmConfig)
+
+	r.Spinner.Message("Rendering the helm chart")
+	if err := r.HelmClient.Install(); err != nil {
+		return err
+	}
+
+	images, err := r.HelmClient.GetImages()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, types.BundleImagesFile))
+	if err != nil {
+		return err
+	}
+	err = r.DockerClient.SaveImages(images, f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	metadata := types.BundleMetadata{
+		CreatedAt:        time.Now().UTC(),
+		RasaCtlVersion:   version.VERSION,
+		HelmChart:        filepath.Join(types.BundleChartDir, filepath.Base(chartFile)),
+		HelmChartVersion: chartVersion,
+		Images:           images,
+	}
+	if err := writeYAMLFile(filepath.Join(dir, types.BundleMetadataFile), metadata); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Writing the bundle archive")
+	if err := utils.CreateArchive(dir, file); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The bundle with the %s helm chart version and %d images has been saved to %s\n", chartVersion, len(images), file)
+	return nil
+}
+
+// useBundle extracts a bundle and configures clients to use the helm chart from the bundle.
+// It returns a path to a temporary directory with the extracted bundle.
+func (r *RasaCtl) useBundle() (string, error) {
+	if !r.isLocalCluster() {
+		return "", xerrors.Errorf("It looks like you don't use a local cluster as a current Kubernetes context, " +
+			"the bundle flag is supported only with kind, k3d, and minikube")
+	}
+
+	dir, err := ioutil.TempDir("", "rasactl-bundle-")
+	if err != nil {
+		return "", err
+	}
+
+	r.Spinner.Message("Extracting the bundle")
+	if err := utils.ExtractArchive(r.Flags.Start.Bundle, dir); err != nil {
+		return dir, err
+	}
+
+	metadata := types.BundleMetadata{}
+	if err := readYAMLFile(filepath.Join(dir, types.BundleMetadataFile), &metadata); err != nil {
+		return dir, xerrors.Errorf("the %s file is not a valid rasactl bundle: %w", r.Flags.Start.Bundle, err)
+	}
+	r.Log.Info("Using bundle", "file", r.Flags.Start.Bundle,
+		"helmChartVersion", metadata.HelmChartVersion, "images", metadata.Images)
+
+	r.Flags.StartUpgrade.Chart = filepath.Join(dir, metadata.HelmChart)
+	helmConfig := r.HelmClient.GetConfiguration()
+	helmConfig.Version = metadata.HelmChartVersion
+	r.HelmClient.SetConfiguration(helmConfig)
+
+	r.bundleImagesFile = filepath.Join(dir, types.BundleImagesFile)
+
+	return dir, nil
+}
+
+// loadBundleImages loads images from a bundle into nodes of the local cluster.
+func (r *RasaCtl) loadBundleImages() error {
+	if r.bundleImagesFile == "" {
+		return nil
+	}
+
+	r.Spinner.Message(fmt.Sprintf("Loading images into %s nodes", r.LocalCluster.GetType()))
+	return r.LocalCluster.LoadImages(r.bundleImagesFile)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	"github.com/RasaHQ/rasactl/pkg/k8s"
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+	"github.com/RasaHQ/rasactl/pkg/utils/cloud"
+)
+
+const (
+	// ingressNginxManifestURL is a URL to the ingress-nginx manifest prepared for kind.
+	ingressNginxManifestURL = "https://raw.githubusercontent.com/kubernetes/ingress-nginx/controller-v1.1.0/deploy/static/provider/kind/deploy.yaml"
+
+	ingressNginxNamespace  = "ingress-nginx"
+	ingressNginxController = "ingress-nginx-controller"
+)
+
+// InitClusterClients initializes clients used to manage a kind cluster. Unlike InitClients,
+// it doesn't require access to a Kubernetes cluster.
+func (r *RasaCtl) InitClusterClients() error {
+	r.Spinner = status.NewSpinner()
+
+	cloudProvider := &cloud.Provider{Log: r.Log}
+	cloudProvider.New()
+	r.CloudProvider = cloudProvider
+
+	dockerClient, err := docker.New(
+		&docker.Docker{
+			Log:     r.Log,
+			Spinner: r.Spinner,
+			Flags:   r.Flags,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	r.DockerClient = dockerClient
+
+	return nil
+}
+
+// ClusterCreate creates a kind cluster, installs the ingress controller, and configures CoreDNS
+// to resolve the local domain used by deployments. If the cluster already exists, only missing
+// components are installed.
+func (r *RasaCtl) ClusterCreate() error {
+	name := r.Flags.Cluster.Name
+
+	nodes, err := r.DockerClient.GetKindClusterNodes(name)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		r.Spinner.Message(fmt.Sprintf("Creating the %s kind cluster", name))
+		if err := r.DockerClient.CreateKindCluster(docker.KindClusterSpec{
+			Name:           name,
+			Image:          r.Flags.Cluster.Image,
+			KubeconfigPath: viper.GetString("kubeconfig"),
+			WaitForReady:   r.Flags.Cluster.WaitTimeout,
+		}); err != nil {
+			return err
+		}
+	} else {
+		r.Log.Info("The kind cluster already exists", "name", name)
+		fmt.Printf("The %s cluster already exists, checking cluster components.\n", name)
+	}
+
+	if err := r.initClusterKubernetesClient(name); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Installing the ingress controller")
+	if err := r.installIngressController(); err != nil {
+		return xerrors.Errorf("can't install the ingress controller: %w", err)
+	}
+
+	r.Spinner.Message("Waiting for the ingress controller to be ready")
+	if err := r.KubernetesClient.WaitForDeployment(ingressNginxNamespace, ingressNginxController, r.Flags.Cluster.WaitTimeout); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Configuring CoreDNS")
+	if err := r.KubernetesClient.ConfigureCoreDNS(types.RasaCtlLocalDomain, ingressNginxNamespace, ingressNginxController); err != nil {
+		return xerrors.Errorf("can't configure CoreDNS: %w", err)
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The %s cluster is ready, the current Kubernetes context is %s.\n", name, kindContextName(name))
+	fmt.Println("Use the 'rasactl start' command to create a deployment.")
+
+	return nil
+}
+
+// ClusterDelete deletes a kind cluster along with all deployments.
+func (r *RasaCtl) ClusterDelete() error {
+	name := r.Flags.Cluster.Name
+
+	nodes, err := r.DockerClient.GetKindClusterNodes(name)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 {
+		return xerrors.Errorf("the %s cluster doesn't exist", name)
+	}
+
+	if !r.Flags.Cluster.Yes {
+		confirmed, _ := utils.AskForConfirmation(
+			fmt.Sprintf("You're about to delete the %s cluster with all deployments in it, are you sure?", name), 5, os.Stdin)
+		if !confirmed {
+			return nil
+		}
+	}
+
+	r.Spinner.Message(fmt.Sprintf("Deleting the %s kind cluster", name))
+	if err := r.DockerClient.DeleteKindCluster(name, viper.GetString("kubeconfig")); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Done!")
+	r.Spinner.Stop()
+
+	return nil
+}
+
+// ClusterStatus prints status of a kind cluster.
+func (r *RasaCtl) ClusterStatus() error {
+	name := r.Flags.Cluster.Name
+	cluster := types.ClusterStatusOutput{
+		Name:              name,
+		Context:           kindContextName(name),
+		Nodes:             []types.ClusterNodeOutput{},
+		IngressController: "unknown",
+	}
+
+	containers, err := r.DockerClient.GetKindClusterNodes(name)
+	if err != nil {
+		return err
+	}
+	cluster.Exists = len(containers) != 0
+
+	readyNodes := map[string]bool{}
+	if cluster.Exists && strings.HasPrefix(containers[0].State, "running") {
+		if err := r.initClusterKubernetesClient(name); err != nil {
+			r.Log.Info("Can't connect to the cluster", "error", err)
+		} else {
+			r.readClusterStatus(&cluster, readyNodes)
+		}
+	}
+
+	for _, container := range containers {
+		nodeName := strings.TrimPrefix(container.Names[0], "/")
+		cluster.Nodes = append(cluster.Nodes, types.ClusterNodeOutput{
+			Name:      nodeName,
+			Role:      container.Labels["io.x-k8s.kind.role"],
+			Container: container.State,
+			Ready:     readyNodes[nodeName],
+		})
+	}
+
+	if !status.IsTableOutput(r.Flags.Cluster.Output) {
+		return status.FprintObject(os.Stdout, cluster, r.Flags.Cluster.Output)
+	}
+
+	if !cluster.Exists {
+		fmt.Printf("The %s cluster doesn't exist, use the 'rasactl cluster create' command to create it.\n", name)
+		return nil
+	}
+
+	coreDNS := "not configured"
+	if cluster.CoreDNSConfigured {
+		coreDNS = "configured"
+	}
+
+	status.PrintTableNoHeader([][]string{
+		{"Name:", cluster.Name},
+		{"Context:", cluster.Context},
+		{"Kubernetes version:", cluster.KubernetesVersion},
+		{"Ingress controller:", cluster.IngressController},
+		{"CoreDNS:", coreDNS},
+	})
+
+	data := [][]string{}
+	for _, node := range cluster.Nodes {
+		data = append(data, []string{node.Name, node.Role, node.Container, fmt.Sprintf("%t", node.Ready)})
+	}
+
+	fmt.Println()
+	status.PrintTable([]string{"Node", "Role", "Container", "Ready"}, data)
+
+	return nil
+}
+
+// readClusterStatus reads status of the cluster components from Kubernetes.
+func (r *RasaCtl) readClusterStatus(cluster *types.ClusterStatusOutput, readyNodes map[string]bool) {
+	nodes, err := r.KubernetesClient.GetNodes()
+	if err != nil {
+		r.Log.Info("Can't list nodes", "error", err)
+		return
+	}
+
+	for _, node := range nodes.Items {
+		cluster.KubernetesVersion = node.Status.NodeInfo.KubeletVersion
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeReady {
+				readyNodes[node.Name] = condition.Status == v1.ConditionTrue
+			}
+		}
+	}
+
+	deployment, err := r.KubernetesClient.GetDeployment(ingressNginxNamespace, ingressNginxController)
+	switch {
+	case errors.IsNotFound(err):
+		cluster.IngressController = "not installed"
+	case err != nil:
+		r.Log.Info("Can't read the ingress controller", "error", err)
+	case deployment.Status.AvailableReplicas != 0:
+		cluster.IngressController = "ready"
+	default:
+		cluster.IngressController = "not ready"
+	}
+
+	configured, err := r.KubernetesClient.IsCoreDNSConfigured(types.RasaCtlLocalDomain)
+	if err != nil {
+		r.Log.Info("Can't read the CoreDNS configuration", "error", err)
+	}
+	cluster.CoreDNSConfigured = configured
+}
+
+// initClusterKubernetesClient initializes the Kubernetes client for a kind cluster
+// created by the 'rasactl cluster create' command.
+func (r *RasaCtl) initClusterKubernetesClient(name string) error {
+	viper.Set("kube-context", kindContextName(name))
+
+	kubernetesClient, err := k8s.New(
+		&k8s.Kubernetes{
+			Log:           r.Log,
+			CloudProvider: r.CloudProvider,
+			Flags:         r.Flags,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	r.KubernetesClient = kubernetesClient
+
+	return nil
+}
+
+func (r *RasaCtl) installIngressController() error {
+	client := &http.Client{Timeout: time.Minute}
+
+	r.Log.Info("Downloading the ingress controller manifest", "url", ingressNginxManifestURL)
+	resp, err := client.Get(ingressNginxManifestURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return xerrors.Errorf("can't download the %s manifest, status code: %d", ingressNginxManifestURL, resp.StatusCode)
+	}
+
+	return r.KubernetesClient.ApplyManifest(resp.Body)
+}
+
+// kindContextName returns a kubeconfig context name for a given kind cluster.
+func kindContextName(name string) string {
+	return fmt.Sprintf("kind-%s", name)
+}
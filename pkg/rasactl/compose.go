@@ -0,0 +1,512 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	dtypes "github.com/docker/docker/api/types"
+	"github.com/spf13/viper"
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasa"
+	rxtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+// IsDockerBackend returns true if deployments run as Docker containers instead of a Kubernetes cluster.
+func (r *RasaCtl) IsDockerBackend() bool {
+	return types.BackendType(viper.GetString("backend")) == types.BackendDocker
+}
+
+// initComposeClients initializes clients used by the Docker backend.
+func (r *RasaCtl) initComposeClients() error {
+	r.Spinner = status.NewSpinner()
+
+	dockerClient, err := docker.New(
+		&docker.Docker{
+			Namespace: r.Namespace,
+			Log:       r.Log,
+			Spinner:   r.Spinner,
+			Flags:     r.Flags,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	r.DockerClient = dockerClient
+
+	return nil
+}
+
+// GetDeployments returns names of deployments managed by rasactl.
+func (r *RasaCtl) GetDeployments() ([]string, error) {
+	if r.IsDockerBackend() {
+		return r.DockerClient.GetComposeDeployments()
+	}
+	return r.KubernetesClient.GetNamespaces()
+}
+
+// IsDeploymentExist checks if a given deployment exists.
+func (r *RasaCtl) IsDeploymentExist(name string) (bool, error) {
+	if r.IsDockerBackend() {
+		if _, err := r.DockerClient.ReadComposeState(name); err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+	return r.KubernetesClient.IsNamespaceExist(name)
+}
+
+// IsDeploymentManageable checks if a given deployment is managed by rasactl.
+func (r *RasaCtl) IsDeploymentManageable() bool {
+	if r.IsDockerBackend() {
+		// Deployments that use the Docker backend exist only if rasactl created them.
+		return true
+	}
+	return r.KubernetesClient.IsNamespaceManageable()
+}
+
+// readState returns the deployment state, the rasactl secret for the Kubernetes backend,
+// or the state file for the Docker backend.
+func (r *RasaCtl) readState() (map[string][]byte, error) {
+	if r.IsDockerBackend() {
+		state, err := r.DockerClient.ReadComposeState(r.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return state.StateData(), nil
+	}
+	return r.KubernetesClient.ReadSecretWithState()
+}
+
+// composeCheckDeploymentStatus returns 'true' as the first value if the deployment exists,
+// and 'true' as the second value if the Rasa X container is running.
+func (r *RasaCtl) composeCheckDeploymentStatus() (bool, bool, error) {
+	isDeployed, err := r.IsDeploymentExist(r.Namespace)
+	if err != nil || !isDeployed {
+		return false, false, err
+	}
+	r.isRasaXDeployed = isDeployed
+
+	containers, err := r.DockerClient.GetComposeContainers(r.Namespace)
+	if err != nil {
+		return isDeployed, false, err
+	}
+
+	for _, c := range containers {
+		if docker.ComposeContainerService(c) == docker.ComposeServiceRasaX && c.State == "running" {
+			r.isRasaXRunning = true
+		}
+	}
+
+	return isDeployed, r.isRasaXRunning, nil
+}
+
+// composeStart creates a new deployment or starts a stopped one.
+func (r *RasaCtl) composeStart() error {
+	r.Log.V(1).Info("Validating deployment name", "name", r.Namespace)
+	if err := utils.ValidateName(r.Namespace); err != nil {
+		return err
+	}
+
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if state == nil {
+		projectPath, err := r.composeProjectPath()
+		if err != nil {
+			return err
+		}
+
+		spec, err := docker.NewComposeSpec(r.Namespace, docker.ComposeRasaXVersion, r.Flags.Start.RasaXPassword, projectPath)
+		if err != nil {
+			return err
+		}
+
+		state = &docker.ComposeState{
+			Spec: spec,
+			Data: map[string]string{types.StateProjectPath: projectPath},
+		}
+		if err := r.DockerClient.SaveComposeState(state); err != nil {
+			return err
+		}
+
+		if projectPath != "" {
+			if err := r.writeStatusFile(projectPath); err != nil {
+				return err
+			}
+		}
+
+		r.Spinner.Message("Deploying Rasa X")
+	} else {
+		r.isRasaXDeployed = true
+		r.Spinner.Message("Starting Rasa X")
+	}
+
+	// Missing containers are created, existing ones are started.
+	if err := r.DockerClient.CreateComposeStack(state.Spec); err != nil {
+		return xerrors.Errorf("%w\nUse 'rasactl logs %s --all' to check logs of the deployment", err, r.Namespace)
+	}
+
+	r.initRasaXClient()
+	r.RasaXClient.Token = state.Spec.RasaXToken
+
+	if err := r.RasaXClient.WaitForRasaX(); err != nil {
+		return err
+	}
+
+	r.Log.Info("Rasa X is ready", "url", r.RasaXClient.URL, "password", state.Spec.RasaXPassword)
+	r.Spinner.Stop()
+	fmt.Println("Ready!")
+
+	rasaXVersion, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		return err
+	}
+
+	if err := r.composeUpdateState(state, rasaXVersion); err != nil {
+		return err
+	}
+
+	if !r.isRasaXDeployed {
+		// Print the status box only if it's a new Rasa X deployment
+		r.Flags.Start.RasaXPassword = state.Spec.RasaXPassword
+		status.PrintRasaXStatus(rasaXVersion, r.RasaXClient.URL, r.Flags)
+	}
+
+	return nil
+}
+
+// composeProjectPath returns a path to a local Rasa project if the --project or --project-path flag is used.
+func (r *RasaCtl) composeProjectPath() (string, error) {
+	if r.Flags.Start.ProjectPath != "" {
+		path, err := os.Stat(r.Flags.Start.ProjectPath)
+		if err != nil {
+			return "", err
+		}
+		if !path.IsDir() {
+			return "", xerrors.Errorf("The %s path can't point to a file, it has to be a directory", r.Flags.Start.ProjectPath)
+		}
+		return r.Flags.Start.ProjectPath, nil
+	}
+
+	if r.Flags.Start.Project {
+		return os.Getwd()
+	}
+
+	return "", nil
+}
+
+// composeUpdateState stores information about the Rasa X version in the deployment state.
+func (r *RasaCtl) composeUpdateState(state *docker.ComposeState, version *rxtypes.VersionEndpointResponse) error {
+	state.Data[types.StateRasaXVersion] = version.RasaX
+	state.Data[types.StateRasaWorkerVersion] = version.Rasa.Worker
+	state.Data[types.StateEnterprise] = "inactive"
+	if version.Enterprise {
+		state.Data[types.StateEnterprise] = "active"
+	}
+
+	return r.DockerClient.SaveComposeState(state)
+}
+
+// composeStop stops containers of the deployment.
+func (r *RasaCtl) composeStop() error {
+	r.Spinner.Message("Stopping Rasa X")
+
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil {
+		return err
+	}
+
+	r.initRasaXClient()
+	if version, err := r.RasaXClient.GetVersionEndpoint(); err == nil {
+		if err := r.composeUpdateState(state, version); err != nil {
+			return err
+		}
+	}
+
+	if err := r.DockerClient.StopComposeStack(r.Namespace); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("Rasa X for the %s deployment has been stopped\n", r.Namespace)
+	return nil
+}
+
+// composeDelete deletes containers, volumes, and the state of the deployment.
+func (r *RasaCtl) composeDelete() error {
+	force := r.Flags.Delete.Force
+
+	msg := "Deleting Rasa X"
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "deployment", r.Namespace)
+
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil && !force {
+		return err
+	}
+
+	if err := r.DockerClient.DeleteComposeStack(r.Namespace); err != nil && !force {
+		return err
+	}
+
+	if err := r.DockerClient.DeleteComposeState(r.Namespace); err != nil && !force {
+		return err
+	}
+
+	if state != nil && state.Spec.ProjectPath != "" {
+		rasactlFile := fmt.Sprintf("%s/.rasactl", state.Spec.ProjectPath)
+		r.Log.V(1).Info("Deleting .rasactl file", "file", rasactlFile)
+		if err := os.Remove(rasactlFile); err != nil {
+			r.Log.V(1).Info("Can't remove .rasactl file", "file", rasactlFile, "error", err)
+		}
+	}
+
+	r.Spinner.Message("Done!")
+	r.Spinner.Stop()
+	return nil
+}
+
+// composeDeploymentStatus returns status for a deployment that uses the Docker backend.
+func (r *RasaCtl) composeDeploymentStatus() (*types.DeploymentStatusOutput, error) {
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := r.DockerClient.GetComposeContainers(r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &types.DeploymentStatusOutput{
+		Name:        r.Namespace,
+		Status:      "stopped",
+		URL:         state.Spec.RasaXURL(),
+		Version:     state.Data[types.StateRasaXVersion],
+		Enterprise:  state.Data[types.StateEnterprise],
+		ProjectPath: "not defined",
+	}
+
+	for _, c := range containers {
+		if docker.ComposeContainerService(c) == docker.ComposeServiceRasaX && c.State == "running" {
+			deployment.Status = "running"
+		}
+	}
+
+	if deployment.Status == "running" {
+		r.initRasaXClient()
+		if versionEndpoint, err := r.RasaXClient.GetVersionEndpoint(); err == nil {
+			deployment.Version = versionEndpoint.RasaX
+			deployment.Enterprise = "inactive"
+			if versionEndpoint.Enterprise {
+				deployment.Enterprise = "active"
+			}
+		}
+
+		if r.Flags.Status.Watch {
+			if health, err := r.RasaXClient.GetHealthEndpoint(); err == nil {
+				deployment.DatabaseMigration = fmt.Sprintf("%s (%.0f%%)",
+					health.DatabaseMigration.Status, health.DatabaseMigration.ProgressInPercent)
+			}
+		}
+	}
+
+	if state.Spec.ProjectPath != "" {
+		deployment.ProjectPath = state.Spec.ProjectPath
+	}
+
+	if r.Flags.Status.Details {
+		for _, c := range containers {
+			name := docker.ComposeContainerService(c)
+			if len(c.Names) != 0 {
+				name = c.Names[0][1:]
+			}
+			deployment.Pods = append(deployment.Pods, types.PodStatusOutput{
+				Name:      name,
+				Condition: c.Status,
+				Status:    c.State,
+			})
+		}
+	}
+
+	return deployment, nil
+}
+
+// composeLogs prints logs for a given service. If the --all flag is used,
+// logs from all services are merged into one stream.
+func (r *RasaCtl) composeLogs(args []string) error {
+	if r.Flags.Logs.Selector != "" || r.Flags.Logs.Previous || r.Flags.Logs.Container != "" {
+		return xerrors.Errorf("The --selector, --previous, and --container flags are not supported with the %s backend", types.BackendDocker)
+	}
+
+	grep, err := compileLogsGrep(r.Flags.Logs.Grep)
+	if err != nil {
+		return err
+	}
+
+	containers, err := r.DockerClient.GetComposeContainers(r.Namespace)
+	if err != nil {
+		return err
+	}
+
+	options := dtypes.ContainerLogsOptions{
+		Follow:     r.Flags.Logs.Follow,
+		Timestamps: r.Flags.Logs.Timestamps,
+	}
+	if r.Flags.Logs.TailLines > 0 {
+		options.Tail = fmt.Sprintf("%d", r.Flags.Logs.TailLines)
+	}
+	if r.Flags.Logs.Since > 0 {
+		options.Since = time.Now().Add(-r.Flags.Logs.Since).Format(time.RFC3339)
+	}
+
+	if r.Flags.Logs.All {
+		w := &syncWriter{w: os.Stdout}
+		wg := sync.WaitGroup{}
+		for _, c := range containers {
+			service := docker.ComposeContainerService(c)
+			stream, err := r.DockerClient.GetContainerLogs(c.ID, options)
+			if err != nil {
+				return err
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer stream.Close()
+				if err := printLogLines(stream, w, logsPrefix(service, service), grep); err != nil {
+					r.Log.V(1).Info("Can't read logs", "service", service, "error", err)
+				}
+			}()
+		}
+		wg.Wait()
+		return nil
+	}
+
+	service := args[1]
+	if service == "" {
+		options := []string{}
+		for _, c := range containers {
+			options = append(options, docker.ComposeContainerService(c))
+		}
+
+		prompt := &survey.Select{
+			Message: "Choose a service:",
+			Options: options,
+		}
+		if err := survey.AskOne(prompt, &service, survey.WithIcons(func(icons *survey.IconSet) {
+			icons.Question.Text = ""
+		})); err != nil {
+			if errors.Is(err, terminal.InterruptErr) {
+				fmt.Println("Interrupted")
+				return nil
+			}
+			return err
+		}
+	}
+
+	for _, c := range containers {
+		if docker.ComposeContainerService(c) != service {
+			continue
+		}
+
+		stream, err := r.DockerClient.GetContainerLogs(c.ID, options)
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+
+		return printLogLines(stream, os.Stdout, "", grep)
+	}
+
+	return xerrors.Errorf("the %s service doesn't exist, available services: %v", service, docker.ComposeServices)
+}
+
+// composeUpdateRasaXConfig configures Rasa X to use Rasa servers running on the local machine.
+// The Rasa X container is recreated with new environment variables.
+func (r *RasaCtl) composeUpdateRasaXConfig(rasaToken string) error {
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil {
+		return err
+	}
+
+	productionPort := r.Flags.ConnectRasa.Port
+	workerPort := r.Flags.ConnectRasa.Port
+	if r.Flags.ConnectRasa.RunSeparateWorker {
+		workerPort++
+	}
+
+	state.Spec.RasaToken = rasaToken
+	state.Spec.RasaProductionURL = fmt.Sprintf("http://host.docker.internal:%d", productionPort)
+	state.Spec.RasaWorkerURL = fmt.Sprintf("http://host.docker.internal:%d", workerPort)
+	if err := r.DockerClient.SaveComposeState(state); err != nil {
+		return err
+	}
+
+	r.Log.Info("Recreating the Rasa X container")
+	return r.DockerClient.RecreateComposeService(state.Spec, docker.ComposeServiceRasaX)
+}
+
+// composeRasaEndpoints returns configuration for a local Rasa server that uses services of the deployment.
+func (r *RasaCtl) composeRasaEndpoints() (*rtypes.EndpointsFile, error) {
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rtypes.EndpointsFile{
+		Models: rtypes.EndpointModelSpec{
+			URL:                  fmt.Sprintf("%s/api/projects/default/models/tags/production", state.Spec.RasaXURL()),
+			Token:                state.Spec.RasaXToken,
+			WaitTimeBetweenPulls: 10,
+		},
+		TrackerStore: rtypes.EndpointTrackerStoreSpec{
+			Type:     "sql",
+			Dialect:  "postgresql",
+			URL:      "127.0.0.1",
+			Port:     int32(state.Spec.PostgreSQLPort),
+			Username: docker.ComposePostgreSQLUsername,
+			Password: state.Spec.PostgreSQLPassword,
+			Db:       "tracker",
+			LoginDb:  docker.ComposePostgreSQLDatabase,
+		},
+		EventBroker: rtypes.EndpointEventBrokerSpec{
+			Type:     "pika",
+			URL:      "127.0.0.1",
+			Port:     int32(state.Spec.RabbitMQPort),
+			Username: docker.ComposeRabbitMQUsername,
+			Password: state.Spec.RabbitMQPassword,
+			Queues:   []string{docker.ComposeRabbitMQQueue},
+		},
+	}, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasa"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+// ConnectActions runs a local action server and connects it to a given deployment.
+// The action endpoint is set for the local Rasa server connected with the connect rasa command,
+// and for Rasa servers that run in the deployment. The changes are reverted when the command exits.
+func (r *RasaCtl) ConnectActions() error {
+	defer r.Cleanup(nil)
+
+	if !r.IsDockerBackend() && !r.isLocalCluster() {
+		return xerrors.Errorf(
+			"It looks like you're not using a local Kubernetes cluster, the deployment can't reach a local action server. " +
+				"Use kind, k3d, or minikube",
+		)
+	}
+
+	stateData, err := r.readState()
+	if err != nil {
+		return err
+	}
+
+	projectPath := string(stateData[types.StateProjectPath])
+	if projectPath == "" {
+		return xerrors.Errorf("The connect actions command requires a deployment that uses a local project, use the --project flag for the start command")
+	}
+
+	port := r.Flags.ConnectActions.Port
+	fileEndpoints := filepath.Join(projectPath, ".endpoints.yaml")
+
+	r.Spinner.Message("Connecting the action server to Rasa X")
+	previous, err := r.setActionEndpoint(fileEndpoints, port)
+	if err != nil {
+		return err
+	}
+	r.addCleanup(func(sig os.Signal) {
+		if err := writeActionEndpoint(fileEndpoints, previous); err != nil {
+			r.Log.Error(err, "Can't restore the action endpoint", "file", fileEndpoints)
+		}
+	})
+	fmt.Printf("The action endpoint has been saved in %s, restart the 'rasactl connect rasa' command to use it\n", fileEndpoints)
+
+	if !r.IsDockerBackend() {
+		if err := r.GetAllHelmValues(); err != nil {
+			return err
+		}
+		app, _ := r.HelmClient.GetValues()["app"].(map[string]interface{})
+		install, existingURL := app["install"], app["existingUrl"]
+		// The action endpoint is restored only if it has been changed by the command.
+		upgraded := false
+		r.addCleanup(func(sig os.Signal) {
+			if upgraded {
+				r.restoreActionEndpoint(install, existingURL)
+			}
+		})
+
+		if err := r.upgradeActionEndpoint(port); err != nil {
+			return err
+		}
+		upgraded = true
+	}
+
+	if r.Flags.ConnectActions.Image != "" {
+		return r.runContainers(map[string]docker.RasaServerSpec{
+			"actions": {
+				Name:        docker.RasaServerContainerName(r.Namespace, "actions"),
+				Image:       r.Flags.ConnectActions.Image,
+				ProjectPath: projectPath,
+				// The entrypoint of the rasa-sdk image is stored in the directory the project is mounted in.
+				Entrypoint: []string{"python", "-m", "rasa_sdk"},
+				Args:       []string{"--actions", "actions", "--port", fmt.Sprintf("%d", port)},
+				Port:       port,
+			},
+		})
+	}
+
+	supervisor := newRasaServerSupervisor(r.Log, path.Join(projectPath, ".rasactl"))
+	supervisor.add("actions", []string{"run", "actions", "--port", fmt.Sprintf("%d", port)}, port, "/health")
+	r.addCleanup(func(sig os.Signal) {
+		supervisor.shutdown(sig)
+	})
+	r.Spinner.Stop()
+
+	return supervisor.run()
+}
+
+// upgradeActionEndpoint configures the deployment to use an action server that runs on a given port of the local machine.
+func (r *RasaCtl) upgradeActionEndpoint(port int) error {
+	if err := r.setHelmConfigurationFromState(); err != nil {
+		return err
+	}
+
+	host := "host.docker.internal"
+	if runtime.GOOS == "linux" {
+		networkGateway, err := r.LocalCluster.GetNetworkGatewayAddress()
+		if err != nil {
+			return err
+		}
+		host = networkGateway
+	}
+
+	url := fmt.Sprintf("http://%s:%d/webhook", host, port)
+	r.HelmClient.SetValues(utils.MergeMaps(r.HelmClient.GetValues(), helm.ValuesActionEndpoint(url)))
+
+	r.Log.V(1).Info("Upgrading configuration for Rasa X deployment", "step", "set the action endpoint", "url", url)
+	return r.HelmClient.Upgrade()
+}
+
+// restoreActionEndpoint restores the configuration of the action server in the deployment to given values.
+// The current values of the deployment are used, so that changes made by other commands running at the same time,
+// e.g. 'rasactl connect rasa', are not reverted.
+func (r *RasaCtl) restoreActionEndpoint(install, existingURL interface{}) {
+	r.Log.Info("Restoring the action endpoint for Rasa X deployment")
+	r.Spinner.Message("Restoring the action endpoint for Rasa X deployment")
+	defer r.Spinner.Stop()
+
+	if err := r.GetAllHelmValues(); err != nil {
+		r.Log.Error(err, "Can't restore the action endpoint for Rasa X deployment")
+		return
+	}
+
+	values := r.HelmClient.GetValues()
+	app, ok := values["app"].(map[string]interface{})
+	if !ok {
+		app = map[string]interface{}{}
+		values["app"] = app
+	}
+	// Values are set directly, merging maps would skip empty values.
+	app["install"] = install
+	app["existingUrl"] = existingURL
+	r.HelmClient.SetValues(values)
+
+	if err := r.HelmClient.Upgrade(); err != nil {
+		r.Log.Error(err, "Can't restore the action endpoint for Rasa X deployment")
+	}
+}
+
+// setActionEndpoint sets the action endpoint in a given endpoints.yaml file, it returns the previous action endpoint.
+// The Rasa server reaches the local machine via the same host as the tracker store.
+func (r *RasaCtl) setActionEndpoint(file string, port int) (*rtypes.EndpointActionSpec, error) {
+	endpoints, err := readEndpointsFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	host := endpoints.TrackerStore.URL
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	previous := endpoints.ActionEndpoint
+	endpoint := &rtypes.EndpointActionSpec{URL: fmt.Sprintf("http://%s:%d/webhook", host, port)}
+
+	r.Log.Info("Saving the action endpoint", "file", file, "url", endpoint.URL)
+	return previous, writeActionEndpoint(file, endpoint)
+}
+
+// writeActionEndpoint sets a given action endpoint in the endpoints.yaml file,
+// the action endpoint is removed if it's nil.
+func writeActionEndpoint(file string, endpoint *rtypes.EndpointActionSpec) error {
+	endpoints, err := readEndpointsFile(file)
+	if err != nil {
+		return err
+	}
+	endpoints.ActionEndpoint = endpoint
+
+	data, err := yaml.Marshal(endpoints)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// readActionEndpoint returns the action endpoint stored in a given endpoints.yaml file,
+// it returns nil if the file doesn't exist or the action endpoint is not set.
+func readActionEndpoint(file string) *rtypes.EndpointActionSpec {
+	endpoints, err := readEndpointsFile(file)
+	if err != nil {
+		return nil
+	}
+	return endpoints.ActionEndpoint
+}
+
+func readEndpointsFile(file string) (*rtypes.EndpointsFile, error) {
+	endpoints := &rtypes.EndpointsFile{}
+
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return endpoints, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, endpoints); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
@@ -18,18 +18,15 @@ package rasactl
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
-	"os/exec"
-	"os/signal"
+	"path"
 	"runtime"
-	"syscall"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
-	"gopkg.in/yaml.v2"
 
+	"github.com/RasaHQ/rasactl/pkg/docker"
 	"github.com/RasaHQ/rasactl/pkg/helm"
 	"github.com/RasaHQ/rasactl/pkg/types"
 	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasa"
@@ -39,10 +36,24 @@ import (
 
 // ConnectRasa connects a local rasa server to a given deployment.
 func (r *RasaCtl) ConnectRasa() error {
+	defer r.Cleanup(nil)
 
-	if r.KubernetesClient.GetBackendType() != types.KubernetesBackendLocal {
+	if r.Flags.ConnectRasa.PortForward {
+		if r.IsDockerBackend() {
+			return xerrors.Errorf("The --port-forward flag is not supported with the %s backend", types.BackendDocker)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		r.Spinner.Message("Forwarding ports")
+		if err := r.startPortForwarding(ctx); err != nil {
+			return err
+		}
+	} else if !r.IsDockerBackend() && !r.isLocalCluster() {
 		return xerrors.Errorf(
-			"It looks like you're not using kind as a backend for Kubernetes cluster, this command is available only if you use kind",
+			"It looks like you're not using a local Kubernetes cluster, use the --port-forward flag, " +
+				"or use kind, k3d, or minikube",
 		)
 	}
 
@@ -50,12 +61,15 @@ func (r *RasaCtl) ConnectRasa() error {
 	rasaToken := uuid.New().String()
 	environmentName := "production-worker"
 
-	stateData, err := r.KubernetesClient.ReadSecretWithState()
+	stateData, err := r.readState()
 	if err != nil {
 		return err
 	}
 
 	configDir := string(stateData[types.StateProjectPath])
+	if r.Flags.ConnectRasa.Watch && configDir == "" {
+		return xerrors.Errorf("The --watch flag requires a deployment that uses a local project, use the --project flag for the start command")
+	}
 	if configDir == "" {
 		configDir = fmt.Sprintf("/tmp/rasactl-%s", r.Namespace)
 
@@ -69,6 +83,15 @@ func (r *RasaCtl) ConnectRasa() error {
 		}
 	}
 
+	var image string
+	if r.Flags.ConnectRasa.Image != "" {
+		r.initRasaXClient()
+		image, err = r.rasaServerImage()
+		if err != nil {
+			return err
+		}
+	}
+
 	fileCreds := fmt.Sprintf("%s/.credentials.yaml", configDir)
 	fileEndpoints := fmt.Sprintf("%s/.endpoints.yaml", configDir)
 
@@ -77,7 +100,7 @@ func (r *RasaCtl) ConnectRasa() error {
 		"--verbose",
 		"--enable-api",
 		"--cors",
-		"'*'",
+		"*",
 		"--auth-token",
 		rasaToken,
 		"--credentials",
@@ -99,12 +122,36 @@ func (r *RasaCtl) ConnectRasa() error {
 
 	r.Log.Info("Connecting Rasa Server to Rasa X")
 
-	if err := r.upgradeDeploymentConfiguration(); err != nil {
-		return err
-	}
+	if r.Flags.ConnectRasa.PortForward {
+		// Ports are forwarded only from the local machine to the cluster.
+		r.Log.Info("Using port forwarding, the configuration of Rasa X is not changed")
+	} else {
+		if !r.IsDockerBackend() {
+			release, err := r.HelmClient.GetStatus()
+			if err != nil {
+				return err
+			}
+			// upgraded stores the revision created by the upgrade, it's used to detect
+			// upgrades made by other commands while the command is running.
+			upgraded := 0
+			r.addCleanup(func(sig os.Signal) {
+				r.restoreDeploymentConfiguration(release.Version, upgraded)
+			})
+
+			if err := r.upgradeDeploymentConfiguration(); err != nil {
+				return err
+			}
 
-	if err := r.updateRasaXConfig(rasaToken); err != nil {
-		return err
+			upgradedRelease, err := r.HelmClient.GetStatus()
+			if err != nil {
+				return err
+			}
+			upgraded = upgradedRelease.Version
+		}
+
+		if err := r.updateRasaXConfig(rasaToken); err != nil {
+			return err
+		}
 	}
 
 	if err := r.saveRasaCredentialsFile(fileCreds); err != nil {
@@ -127,82 +174,65 @@ func (r *RasaCtl) ConnectRasa() error {
 		return err
 	}
 
-	msg := "Starting Rasa Server"
-	r.Spinner.Message(msg)
-	r.Log.Info(msg, "args", mutualArgs)
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
-	ready := make(chan bool, 1)
-
-	go func() {
-		sig := <-sigs
-		fmt.Println()
-		fmt.Println(sig)
-		ready <- true
-	}()
-
-	go func(ready chan bool) {
-		done := make(chan bool, 1)
-		args := mutualArgs
-		args = append(args,
-			"-p",
-			fmt.Sprintf("%d", productionPort),
-		)
-		r.runRasaServer(environmentName, args, done, ready)
-		<-done
-	}(ready)
+	if r.Flags.ConnectRasa.Watch {
+		// Models are uploaded with the credentials of the current user, check them before starting.
+		if _, err := r.getAuthToken(); err != nil {
+			return err
+		}
 
-	if r.Flags.ConnectRasa.RunSeparateWorker {
-		r.Log.Info("Running separate Rasa X server for the worker environment")
-		go func(ready chan bool) {
-			done := make(chan bool, 1)
-			args := mutualArgs
-			args = append(args,
-				"-p",
-				fmt.Sprintf("%d", workerPort),
-			)
-			r.runRasaServer("worker", args, done, ready)
-			<-done
-		}(ready)
-	}
-	r.Spinner.Stop()
-	<-ready
-	fmt.Println("exiting")
+		watchCtx, cancelWatch := context.WithCancel(context.Background())
+		defer cancelWatch()
 
-	return nil
-}
+		go func() {
+			if err := r.watchAndTrain(watchCtx, configDir, image, rasaToken, productionPort); err != nil {
+				fmt.Printf("Can't watch the project: %s\n", err)
+			}
+		}()
+	}
+
+	if image != "" {
+		args := []string{
+			"run",
+			"--verbose",
+			"--enable-api",
+			"--cors",
+			"*",
+			"--auth-token",
+			rasaToken,
+			"--credentials",
+			path.Join(docker.RasaServerWorkDir, ".credentials.yaml"),
+			"--endpoints",
+			path.Join(docker.RasaServerWorkDir, ".endpoints.yaml"),
+		}
+		args = append(args, r.Flags.ConnectRasa.ExtraArgs...)
 
-func (r *RasaCtl) runRasaServer(environment string, args []string, done chan bool, ready chan bool) {
+		ports := map[string]int{environmentName: productionPort}
+		if r.Flags.ConnectRasa.RunSeparateWorker {
+			ports["worker"] = workerPort
+		}
 
-	cmd := exec.Command("rasa", args...)
-	stdout, err := cmd.StderrPipe()
-	if err != nil {
-		panic(err)
+		return r.runRasaServerContainers(image, configDir, args, ports)
 	}
 
-	if err = cmd.Start(); err != nil {
-		panic(err)
-	}
+	r.Log.Info("Starting Rasa Server", "args", mutualArgs)
 
-	// get real-time output
-	for {
-		tmp := make([]byte, 1024)
-		_, err := stdout.Read(tmp)
-		fmt.Printf("(%s) %s", environment, string(tmp))
-		if err != nil {
-			break
-		}
+	serverArgs := func(port int) []string {
+		args := append([]string{}, mutualArgs...)
+		return append(args, "-p", fmt.Sprintf("%d", port))
 	}
 
-	fmt.Println("awaiting signal")
-	<-ready
-	fmt.Println("exiting")
-	if err = cmd.Wait(); err != nil {
-		panic(err)
+	supervisor := newRasaServerSupervisor(r.Log, path.Join(configDir, ".rasactl"))
+	supervisor.add(environmentName, serverArgs(productionPort), productionPort, "/")
+	if r.Flags.ConnectRasa.RunSeparateWorker {
+		r.Log.Info("Running separate Rasa X server for the worker environment")
+		supervisor.add("worker", serverArgs(workerPort), workerPort, "/")
 	}
-	fmt.Println("done", cmd.ProcessState)
-	done <- true
+	r.addCleanup(func(sig os.Signal) {
+		supervisor.shutdown(sig)
+	})
+	r.Spinner.Stop()
 
+	return supervisor.run()
 }
 
 func (r *RasaCtl) getRasaXNodePortURL() (string, error) {
@@ -211,7 +241,12 @@ func (r *RasaCtl) getRasaXNodePortURL() (string, error) {
 		return "", err
 	}
 
-	url := fmt.Sprintf("http://127.0.0.1:%d", rasaXNodePort)
+	host, err := r.LocalCluster.GetNodePortHost()
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("http://%s:%d", host, rasaXNodePort)
 	return url, nil
 }
 
@@ -221,54 +256,92 @@ func (r *RasaCtl) saveRasaCredentialsFile(file string) error {
 		return err
 	}
 
-	creds := rtypes.CredentialsFile{}
-	creds.Rasa.URL = fmt.Sprintf("%s/api", url)
+	creds := rasaCredentials(url)
+	if r.Flags.ConnectRasa.Image != "" {
+		creds.Rasa.URL = rasaServerURL(creds.Rasa.URL)
+	}
 
 	r.Log.Info("Saving credentials.yaml configuration file", "file", file)
 
-	data, err := yaml.Marshal(&creds)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(file, data, 0644)
+	return writeConfigurationFile(file, creds)
 }
 
 func (r *RasaCtl) saveRasaEndpointsFile(file string) error {
-	url, err := r.GetRasaXURL()
+	var endpoints *rtypes.EndpointsFile
+	var err error
+	switch {
+	case r.IsDockerBackend():
+		endpoints, err = r.composeRasaEndpoints()
+	case r.forwardedPorts != nil:
+		endpoints, err = r.rasaEndpoints(r.forwardedPorts.rasaXURL(),
+			int32(r.forwardedPorts.postgreSQL), int32(r.forwardedPorts.rabbitMQ))
+	default:
+		endpoints, err = r.nodePortRasaEndpoints()
+	}
 	if err != nil {
 		return err
 	}
 
-	token, err := r.GetRasaXToken()
+	// The action endpoint is set by the connect actions command, keep it if it's already configured.
+	endpoints.ActionEndpoint = readActionEndpoint(file)
+
+	if r.Flags.ConnectRasa.Image != "" {
+		endpoints.Models.URL = rasaServerURL(endpoints.Models.URL)
+		endpoints.TrackerStore.URL = rasaServerHost(endpoints.TrackerStore.URL)
+		endpoints.EventBroker.URL = rasaServerHost(endpoints.EventBroker.URL)
+		if endpoints.ActionEndpoint != nil {
+			endpoints.ActionEndpoint.URL = rasaServerURL(endpoints.ActionEndpoint.URL)
+		}
+	}
+
+	r.Log.Info("Saving endpoints.yaml configuration file", "file", file)
+
+	return writeConfigurationFile(file, endpoints)
+}
+
+// nodePortRasaEndpoints returns configuration for a local Rasa server that uses services exposed by a local cluster.
+func (r *RasaCtl) nodePortRasaEndpoints() (*rtypes.EndpointsFile, error) {
+	url, err := r.GetRasaXURL()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	psqlNodePort, err := r.KubernetesClient.GetPostgreSQLSvcNodePort()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rabbitNodePort, err := r.KubernetesClient.GetRabbitMqSvcNodePort()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return r.rasaEndpoints(url, psqlNodePort, rabbitNodePort)
+}
+
+// rasaEndpoints returns configuration for a local Rasa server that uses
+// services of a deployment available on given local ports.
+func (r *RasaCtl) rasaEndpoints(url string, psqlPort, rabbitPort int32) (*rtypes.EndpointsFile, error) {
+	token, err := r.GetRasaXToken()
+	if err != nil {
+		return nil, err
 	}
 
 	usernamePsql, passwordPsql, err := r.KubernetesClient.GetPostgreSQLCreds()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	usernameRabbit, passwordRabbit, err := r.KubernetesClient.GetRabbitMqCreds()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := r.GetAllHelmValues(); err != nil {
-		return err
+		return nil, err
 	}
 
-	endpoints := rtypes.EndpointsFile{
+	return &rtypes.EndpointsFile{
 		Models: rtypes.EndpointModelSpec{
 			URL:                  fmt.Sprintf("%s/api/projects/default/models/tags/production", url),
 			Token:                token,
@@ -278,7 +351,7 @@ func (r *RasaCtl) saveRasaEndpointsFile(file string) error {
 			Type:     "sql",
 			Dialect:  "postgresql",
 			URL:      "127.0.0.1",
-			Port:     psqlNodePort,
+			Port:     psqlPort,
 			Username: usernamePsql,
 			Password: passwordPsql,
 			Db:       "tracker",
@@ -287,20 +360,12 @@ func (r *RasaCtl) saveRasaEndpointsFile(file string) error {
 		EventBroker: rtypes.EndpointEventBrokerSpec{
 			Type:     "pika",
 			URL:      "127.0.0.1",
-			Port:     rabbitNodePort,
+			Port:     rabbitPort,
 			Username: usernameRabbit,
 			Password: passwordRabbit,
 			Queues:   []string{r.HelmClient.GetValues()["rasa"].(map[string]interface{})["rabbitQueue"].(string)},
 		},
-	}
-
-	r.Log.Info("Saving endpoints.yaml configuration file", "file", file)
-
-	data, err := yaml.Marshal(&endpoints)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(file, data, 0644)
+	}, nil
 }
 
 func (r *RasaCtl) saveEnvironments(token string) error {
@@ -335,8 +400,9 @@ func (r *RasaCtl) saveEnvironments(token string) error {
 	return r.RasaXClient.SaveEnvironments(configSpec)
 }
 
-func (r *RasaCtl) upgradeDeploymentConfiguration() error {
-
+// setHelmConfigurationFromState sets the chart version and the release name of the helm client
+// to values stored in the deployment state.
+func (r *RasaCtl) setHelmConfigurationFromState() error {
 	state, err := r.KubernetesClient.ReadSecretWithState()
 	if err != nil {
 		return err
@@ -348,6 +414,15 @@ func (r *RasaCtl) upgradeDeploymentConfiguration() error {
 	helmConfig.ReleaseName = string(state[types.StateHelmReleaseName])
 	r.HelmClient.SetConfiguration(helmConfig)
 
+	return nil
+}
+
+func (r *RasaCtl) upgradeDeploymentConfiguration() error {
+
+	if err := r.setHelmConfigurationFromState(); err != nil {
+		return err
+	}
+
 	r.HelmClient.SetValues(
 		utils.MergeMaps(r.HelmClient.GetValues(), helm.ValuesRabbitMQNodePort(),
 			helm.ValuesPostgreSQLNodePort(), helm.ValuesRasaXNodePort(),
@@ -372,13 +447,13 @@ func (r *RasaCtl) upgradeDeploymentConfiguration() error {
 		helm.ValuesHostNetworkRasaX(), helm.ValuesSetRasaXHost(rasaXHost))
 
 	if runtime.GOOS == "linux" {
-		kindNetworkGateway, err := r.DockerClient.GetKindNetworkGatewayAddress()
+		networkGateway, err := r.LocalCluster.GetNetworkGatewayAddress()
 		if err != nil {
 			return err
 		}
-		helmValues = utils.MergeMaps(helmValues, helm.ValuesSetRasaXHostAliases(kindNetworkGateway))
+		helmValues = utils.MergeMaps(helmValues, helm.ValuesSetRasaXHostAliases(networkGateway))
 
-		r.Log.V(1).Info("KinD network gateway", "address", kindNetworkGateway)
+		r.Log.V(1).Info("Local cluster network gateway", "type", r.LocalCluster.GetType(), "address", networkGateway)
 	}
 
 	r.HelmClient.SetValues(helmValues)
@@ -387,6 +462,41 @@ func (r *RasaCtl) upgradeDeploymentConfiguration() error {
 
 }
 
+// restoreDeploymentConfiguration rolls back the deployment to a given revision,
+// it reverts changes made by upgradeDeploymentConfiguration, e.g. services exposed via node ports.
+//
+// The rollback would also revert upgrades made after the upgraded revision, e.g. the action endpoint set by
+// the 'rasactl connect actions' command running at the same time, in that case the deployment is not rolled back.
+func (r *RasaCtl) restoreDeploymentConfiguration(revision, upgraded int) {
+	release, err := r.HelmClient.GetStatus()
+	if err != nil {
+		r.Log.Error(err, "Can't get the status of the helm release")
+		return
+	}
+
+	if release.Version == revision {
+		return
+	}
+
+	if upgraded != 0 && release.Version != upgraded {
+		r.Log.Info("The deployment has been upgraded by another command, skipping the rollback",
+			"revision", revision, "upgradedRevision", upgraded, "currentRevision", release.Version)
+		r.Spinner.Stop()
+		fmt.Printf("The %s deployment has been upgraded by another command (revision %d), "+
+			"the configuration changed by the 'rasactl connect rasa' command is not reverted. "+
+			"Use 'rasactl rollback %s %d' once other commands exit to revert it.\n",
+			r.Namespace, release.Version, r.Namespace, revision)
+		return
+	}
+
+	r.Log.Info("Restoring configuration for Rasa X deployment", "revision", revision)
+	r.Spinner.Message("Restoring configuration for Rasa X deployment")
+	if err := r.HelmClient.Rollback(revision); err != nil {
+		r.Log.Error(err, "Can't restore configuration for Rasa X deployment")
+	}
+	r.Spinner.Stop()
+}
+
 func (r *RasaCtl) updateRasaXConfig(rasaToken string) error {
 	r.initRasaXClient()
 
@@ -402,6 +512,11 @@ func (r *RasaCtl) updateRasaXConfig(rasaToken string) error {
 			return err
 		}
 
+	} else if r.IsDockerBackend() {
+		r.Log.Info("Updating configuration for Rasa X")
+		if err := r.composeUpdateRasaXConfig(rasaToken); err != nil {
+			return err
+		}
 	} else {
 		r.Log.Info("Updating configuration for Rasa X")
 		if err := r.KubernetesClient.UpdateRasaXConfig(rasaToken); err != nil {
@@ -0,0 +1,172 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	dtypes "github.com/docker/docker/api/types"
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/docker"
+)
+
+// rasaServerImage returns an image used to run a Rasa server in a container.
+// If the image passed with the --image flag doesn't have a tag,
+// the tag is set to a Rasa version that is compatible with the deployment.
+func (r *RasaCtl) rasaServerImage() (string, error) {
+	image := r.Flags.ConnectRasa.Image
+	if imageHasTag(image) {
+		return image, nil
+	}
+
+	version, err := r.compatibleRasaVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s:%s", image, version), nil
+}
+
+// compatibleRasaVersion returns a Rasa version compatible with the deployment.
+// The version reported by the /api/version endpoint is used first,
+// if it's not available then the version defined in the helm chart values is used.
+func (r *RasaCtl) compatibleRasaVersion() (string, error) {
+	version, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		r.Log.V(1).Info("Can't get the version endpoint", "error", err)
+	} else {
+		for _, v := range []string{version.Rasa.Worker, version.Rasa.Production} {
+			if v != "" && v != "0.0.0" {
+				r.Log.V(1).Info("Using Rasa version reported by Rasa X", "version", v)
+				return v, nil
+			}
+		}
+	}
+
+	if !r.IsDockerBackend() {
+		if err := r.GetAllHelmValues(); err != nil {
+			return "", err
+		}
+		if rasa, ok := r.HelmClient.GetValues()["rasa"].(map[string]interface{}); ok {
+			if v, ok := rasa["version"].(string); ok && v != "" {
+				r.Log.V(1).Info("Using Rasa version defined in the helm chart values", "version", v)
+				return v, nil
+			}
+		}
+	}
+
+	return "", xerrors.Errorf(
+		"Can't determine a Rasa version compatible with the deployment, pass an image with a tag, e.g. --image %s:2.8.15",
+		r.Flags.ConnectRasa.Image,
+	)
+}
+
+// imageHasTag checks if a given image reference includes a tag or a digest.
+func imageHasTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return true
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	return strings.Contains(name, ":")
+}
+
+// rasaServerURL returns a URL that is accessible from a Rasa server container.
+// URLs that point to the local machine are rewritten to the address of the local machine
+// that is accessible from the container.
+func rasaServerURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !isLocalHost(u.Hostname()) {
+		return rawURL
+	}
+
+	if port := u.Port(); port != "" {
+		u.Host = net.JoinHostPort(docker.RasaServerHost(), port)
+	} else {
+		u.Host = docker.RasaServerHost()
+	}
+	return u.String()
+}
+
+// rasaServerHost returns a host that is accessible from a Rasa server container.
+func rasaServerHost(host string) string {
+	if isLocalHost(host) {
+		return docker.RasaServerHost()
+	}
+	return host
+}
+
+func isLocalHost(host string) bool {
+	return host == "127.0.0.1" || host == "localhost"
+}
+
+// runRasaServerContainers runs Rasa servers as Docker containers and prints their logs.
+func (r *RasaCtl) runRasaServerContainers(image, configDir string, args []string, ports map[string]int) error {
+	specs := map[string]docker.RasaServerSpec{}
+	for environment, port := range ports {
+		specs[environment] = docker.RasaServerSpec{
+			Name:        docker.RasaServerContainerName(r.Namespace, environment),
+			Image:       image,
+			ProjectPath: configDir,
+			Args:        append(append([]string{}, args...), "-p", fmt.Sprintf("%d", port)),
+			Port:        port,
+		}
+	}
+
+	return r.runContainers(specs)
+}
+
+// runContainers runs given containers and prints their logs, each line is prefixed with the name of the container spec.
+// The containers are removed by Cleanup if one of them exits, or if rasactl is interrupted.
+func (r *RasaCtl) runContainers(specs map[string]docker.RasaServerSpec) error {
+	exited := make(chan string, len(specs))
+	for name, spec := range specs {
+		containerName := spec.Name
+
+		r.Spinner.Message(fmt.Sprintf("Starting %s (%s)", name, spec.Image))
+		r.Log.Info("Starting a container", "name", containerName, "image", spec.Image, "args", spec.Args)
+
+		r.addCleanup(func(sig os.Signal) {
+			if err := r.DockerClient.DeleteRasaServer(containerName); err != nil {
+				r.Log.Error(err, "Can't remove the container", "name", containerName)
+			}
+		})
+		if err := r.DockerClient.RunRasaServer(spec); err != nil {
+			return err
+		}
+
+		logs, err := r.DockerClient.GetContainerLogs(containerName, dtypes.ContainerLogsOptions{Follow: true})
+		if err != nil {
+			return err
+		}
+
+		go func(name string) {
+			defer logs.Close()
+
+			printPrefixed(name, logs)
+			exited <- name
+		}(name)
+	}
+	r.Spinner.Stop()
+
+	name := <-exited
+
+	return xerrors.Errorf("The %s container has exited, check the logs above for details", name)
+}
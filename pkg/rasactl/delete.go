@@ -28,6 +28,11 @@ func (r *RasaCtl) Delete() error {
 	force := r.Flags.Delete.Force
 	prune := r.Flags.Delete.Prune
 
+	if r.IsDockerBackend() {
+		// Volumes of the deployment are always removed, there is no namespace to prune.
+		return r.composeDelete()
+	}
+
 	if prune && !r.confirmPrune() {
 		return nil
 	}
@@ -59,19 +64,32 @@ func (r *RasaCtl) Delete() error {
 		}
 	}
 
-	if (r.DockerClient.GetKind().ControlPlaneHost != "" && string(state[types.StateProjectPath]) != "") || force {
+	if r.isLocalCluster() && (string(state[types.StateProjectPath]) != "" || force) {
 		r.Spinner.Message("Deleting persistent volume")
 		if err := r.KubernetesClient.DeleteVolume(); err != nil && !force {
 			return err
 		}
 
-		r.Spinner.Message("Deleting a kind node")
-		nodeName := fmt.Sprintf("kind-%s", r.Namespace)
-		r.Log.Info("Deleting a kind node", "node", nodeName)
-		if err := r.DockerClient.DeleteKindNode(nodeName); err != nil && !force {
+		r.Spinner.Message(fmt.Sprintf("Deleting a %s node", r.LocalCluster.GetType()))
+		nodeName := r.LocalCluster.GetNodeName()
+		r.Log.Info("Deleting a node", "type", r.LocalCluster.GetType(), "node", nodeName)
+		if err := r.LocalCluster.DeleteNode(); err != nil && !force {
 			return err
 		}
-		if err := r.KubernetesClient.DeleteNode(nodeName); err != nil && !force {
+		if nodeName != "" {
+			if err := r.KubernetesClient.DeleteNode(nodeName); err != nil && !force {
+				return err
+			}
+		}
+	}
+
+	if r.isProjectSynced(string(state[types.StateProjectPath])) && !prune {
+		r.Spinner.Message("Deleting the sync pod and volume")
+		if err := r.KubernetesClient.DeleteSyncPod(); err != nil && !force {
+			return err
+		}
+
+		if err := r.KubernetesClient.DeleteVolume(); err != nil && !force {
 			return err
 		}
 	}
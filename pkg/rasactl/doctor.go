@@ -0,0 +1,303 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/credentials"
+	"github.com/RasaHQ/rasactl/pkg/credentials/helpers"
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	"github.com/RasaHQ/rasactl/pkg/k8s"
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+	"github.com/RasaHQ/rasactl/pkg/utils/cloud"
+)
+
+// Doctor runs diagnostic checks for the environment used by rasactl and prints results.
+//
+// The checks don't require initialized clients, each check initializes clients that it needs,
+// so that problems with one component don't prevent checking the others.
+// An error is returned if at least one check fails.
+func (r *RasaCtl) Doctor() error {
+	checks := r.runDoctorChecks()
+
+	switch r.Flags.Doctor.Output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(checks); err != nil {
+			return err
+		}
+	default:
+		printDoctorChecks(checks)
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.Status == types.DoctorCheckFail {
+			failed++
+		}
+	}
+
+	if failed != 0 {
+		return xerrors.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+
+	return nil
+}
+
+func (r *RasaCtl) runDoctorChecks() []types.DoctorCheck {
+	checks := []types.DoctorCheck{r.doctorCheckDocker()}
+
+	kubernetesChecks := r.doctorCheckKubernetes()
+	checks = append(checks, kubernetesChecks...)
+	checks = append(checks,
+		r.doctorCheckCloudProvider(),
+		r.doctorCheckLocalDomain(),
+		r.doctorCheckRasa(),
+		r.doctorCheckCredentialsStore(),
+	)
+
+	return checks
+}
+
+func (r *RasaCtl) doctorCheckDocker() types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Docker"}
+
+	dockerClient := &docker.Docker{
+		Log:   r.Log,
+		Flags: r.Flags,
+	}
+	if _, err := docker.New(dockerClient); err != nil {
+		check.Status = types.DoctorCheckFail
+		check.Message = fmt.Sprintf("can't initialize the Docker client: %s", err)
+		check.Hint = "Check the DOCKER_HOST environment variable."
+		return check
+	}
+
+	ping, err := dockerClient.Client.Ping(dockerClient.Ctx)
+	if err != nil {
+		check.Status = types.DoctorCheckFail
+		check.Message = fmt.Sprintf("Docker is not reachable: %s", err)
+		check.Hint = "Start Docker and make sure that the current user has access to the Docker socket."
+		return check
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = fmt.Sprintf("Docker is reachable, API version %s", ping.APIVersion)
+	return check
+}
+
+// doctorCheckKubernetes checks access to a Kubernetes cluster, the local cluster backend,
+// and available ingress controllers.
+func (r *RasaCtl) doctorCheckKubernetes() []types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Kubernetes"}
+
+	kubernetesClient, err := k8s.New(
+		&k8s.Kubernetes{
+			Log:           r.Log,
+			CloudProvider: &cloud.Provider{Log: r.Log},
+			Flags:         r.Flags,
+		},
+	)
+	if err != nil {
+		check.Status = types.DoctorCheckFail
+		check.Message = fmt.Sprintf("can't load the Kubernetes configuration: %s", err)
+		check.Hint = "Use the --kubeconfig and --kube-context flags to choose a kubeconfig file and a context."
+		return []types.DoctorCheck{check}
+	}
+
+	if _, err := kubernetesClient.GetNamespaces(); err != nil {
+		check.Status = types.DoctorCheckFail
+		check.Message = fmt.Sprintf("the Kubernetes cluster is not reachable: %s", err)
+		check.Hint = "Make sure that the cluster is running, use 'kind create cluster' to create a local cluster."
+		return []types.DoctorCheck{check}
+	}
+
+	backendType := kubernetesClient.GetBackendType()
+	check.Status = types.DoctorCheckPass
+	check.Message = fmt.Sprintf("the Kubernetes cluster is reachable, backend: %s", backendType)
+
+	return []types.DoctorCheck{
+		check,
+		r.doctorCheckLocalCluster(kubernetesClient, backendType),
+		r.doctorCheckIngress(kubernetesClient),
+	}
+}
+
+func (r *RasaCtl) doctorCheckLocalCluster(kubernetesClient k8s.KubernetesInterface, backendType types.KubernetesBackendType) types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Local cluster"}
+
+	node, err := kubernetesClient.GetControlPlaneNode()
+	if err != nil {
+		check.Status = types.DoctorCheckFail
+		check.Message = fmt.Sprintf("can't read cluster nodes: %s", err)
+		check.Hint = "Make sure that the current user is allowed to list nodes."
+		return check
+	}
+
+	clusterType := docker.DetectLocalClusterType(node)
+	if clusterType == types.LocalClusterUnknown {
+		check.Status = types.DoctorCheckWarn
+		check.Message = "the current Kubernetes context is not a kind, k3d, or minikube cluster"
+		if backendType == types.KubernetesBackendLocal {
+			check.Hint = "Use 'rasactl cluster create' to create a kind cluster, or use the --kube-context flag to switch to a local context. " +
+				"Features that use a local project (--project, --project-path) require kind, k3d, or minikube."
+		}
+		return check
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = fmt.Sprintf("%s control plane: %s, version %s", clusterType, node.Name, node.Status.NodeInfo.KubeletVersion)
+	return check
+}
+
+func (r *RasaCtl) doctorCheckIngress(kubernetesClient k8s.KubernetesInterface) types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Ingress controller"}
+
+	ingressClasses, err := kubernetesClient.GetIngressClasses()
+	if err != nil {
+		check.Status = types.DoctorCheckWarn
+		check.Message = fmt.Sprintf("can't read ingress classes: %s", err)
+		return check
+	}
+
+	if len(ingressClasses) == 0 {
+		check.Status = types.DoctorCheckWarn
+		check.Message = "no ingress class found"
+		check.Hint = "An ingress controller is required if ingress is enabled in helm values, " +
+			"see https://kind.sigs.k8s.io/docs/user/ingress/ to install it in kind."
+		return check
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = fmt.Sprintf("ingress classes: %s", strings.Join(ingressClasses, ", "))
+	return check
+}
+
+func (r *RasaCtl) doctorCheckCloudProvider() types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Cloud provider", Status: types.DoctorCheckPass}
+
+	cloudProvider := &cloud.Provider{Log: r.Log}
+	if provider := cloudProvider.New(); provider != types.CloudProviderUnknown {
+		check.Message = fmt.Sprintf("detected %s, external IP: %s", provider, cloudProvider.ExternalIP)
+		return check
+	}
+
+	check.Message = "no cloud provider detected, running locally"
+	return check
+}
+
+func (r *RasaCtl) doctorCheckLocalDomain() types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Local domain"}
+
+	host := fmt.Sprintf("doctor.%s", types.RasaCtlLocalDomain)
+	addresses, err := net.LookupHost(host)
+	if err != nil || len(addresses) == 0 {
+		check.Status = types.DoctorCheckWarn
+		check.Message = fmt.Sprintf("the %s name doesn't resolve", host)
+		check.Hint = fmt.Sprintf("Deployments are available via <DEPLOYMENT-NAME>.%s, add the name of a deployment to /etc/hosts "+
+			"or configure a local DNS resolver to resolve *.%s to 127.0.0.1.", types.RasaCtlLocalDomain, types.RasaCtlLocalDomain)
+		return check
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = fmt.Sprintf("*.%s resolves to %s", types.RasaCtlLocalDomain, strings.Join(addresses, ", "))
+	return check
+}
+
+func (r *RasaCtl) doctorCheckRasa() types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Rasa OSS"}
+
+	if !utils.CommandExists("rasa") {
+		check.Status = types.DoctorCheckWarn
+		check.Message = "the rasa command is not found in PATH"
+		check.Hint = "The 'rasactl connect rasa' command requires Rasa OSS, use 'pip install rasa' to install it."
+		return check
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = "the rasa command is available"
+	return check
+}
+
+// doctorCheckCredentialsStore checks if the credentials helper used by the 'rasactl auth' command works
+// by storing and removing a test entry.
+func (r *RasaCtl) doctorCheckCredentialsStore() types.DoctorCheck {
+	check := types.DoctorCheck{Name: "Credentials store"}
+
+	hint := ""
+	if runtime.GOOS == "linux" {
+		hint = "rasactl uses pass to store credentials, install pass and run 'pass init <GPG-ID>' to initialize the store."
+
+		if !utils.CommandExists("pass") {
+			check.Status = types.DoctorCheckWarn
+			check.Message = "the pass command is not found in PATH"
+			check.Hint = hint
+			return check
+		}
+	}
+
+	credsStore := credentials.Credentials{
+		Namespace: "doctor",
+		Helper:    helpers.Helper,
+	}
+
+	if err := credsStore.Set("rasactl-doctor", "rasactl", "rasactl"); err != nil {
+		check.Status = types.DoctorCheckWarn
+		check.Message = fmt.Sprintf("can't store credentials: %s", err)
+		check.Hint = hint
+		return check
+	}
+
+	if err := credsStore.Delete("rasactl-doctor"); err != nil {
+		r.Log.Info("Can't delete test credentials", "error", err)
+	}
+
+	check.Status = types.DoctorCheckPass
+	check.Message = "the credentials store is working"
+	return check
+}
+
+func printDoctorChecks(checks []types.DoctorCheck) {
+	data := [][]string{}
+	hints := []string{}
+
+	for _, check := range checks {
+		data = append(data, []string{check.Name, strings.ToUpper(string(check.Status)), check.Message})
+		if check.Hint != "" {
+			hints = append(hints, fmt.Sprintf("  * %s: %s", check.Name, check.Hint))
+		}
+	}
+
+	status.PrintTable([]string{"Check", "Result", "Details"}, data)
+
+	if len(hints) != 0 {
+		fmt.Println()
+		fmt.Println("Hints:")
+		fmt.Println(strings.Join(hints, "\n"))
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+)
+
+// isDryRun returns true if changes should be only rendered without applying them.
+func (r *RasaCtl) isDryRun() bool {
+	return r.Flags.StartUpgrade.DryRun || r.Flags.StartUpgrade.Diff
+}
+
+// dryRun renders the helm chart with merged values and prints the rendered manifest,
+// or a diff against the current helm release if the --diff flag is used.
+// No changes are applied, values of secrets are redacted in both cases.
+func (r *RasaCtl) dryRun() error {
+	r.Spinner.Message("Rendering the helm chart")
+	currentManifest, renderedManifest, err := r.renderManifest()
+	if err != nil {
+		return err
+	}
+	r.Spinner.Stop()
+
+	if !r.Flags.StartUpgrade.Diff {
+		// The manifest contains secrets, e.g. passwords reused from the current helm release.
+		manifest, err := helm.RedactManifest(renderedManifest)
+		if err != nil {
+			return err
+		}
+		fmt.Print(manifest)
+		return nil
+	}
+
+	diff, err := helm.ManifestDiff(currentManifest, renderedManifest)
+	if err != nil {
+		return err
+	}
+
+	if diff == "" {
+		fmt.Printf("No changes for the %s deployment.\n", r.Namespace)
+		return nil
+	}
+	fmt.Print(diff)
+
+	return nil
+}
+
+// renderManifest renders the helm chart in the dry run mode.
+// It returns a manifest of the current helm release and the rendered manifest.
+func (r *RasaCtl) renderManifest() (string, string, error) {
+	helmConfig := r.HelmClient.GetConfiguration()
+	helmConfig.DryRun = true
+	r.HelmClient.SetConfiguration(helmConfig)
+	defer func() {
+		helmConfig.DryRun = false
+	}()
+
+	currentManifest := ""
+	if r.isRasaXDeployed {
+		release, err := r.HelmClient.GetStatus()
+		if err != nil {
+			return "", "", err
+		}
+		currentManifest = release.Manifest
+
+		if err := r.HelmClient.Upgrade(); err != nil {
+			return "", "", err
+		}
+	} else {
+		if r.Flags.Start.ProjectPath != "" || r.Flags.Start.Project {
+			// A volume isn't created in the dry run mode, use a name that would be used by the volume.
+			r.HelmClient.SetPersistanceVolumeClaimName(fmt.Sprintf("rasactl-pvc-%s", r.Namespace))
+		}
+
+		if err := r.HelmClient.Install(); err != nil {
+			return "", "", err
+		}
+	}
+
+	return currentManifest, r.HelmClient.GetManifest(), nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasa"
+)
+
+// lockStoreDb is a Redis database used by the lock store of Rasa servers.
+const lockStoreDb = 1
+
+// Endpoints saves the endpoints.yaml configuration for a Rasa server or another tool that uses services of the deployment.
+// For the Kubernetes backend, the services are accessed via local ports forwarded with the port-forward command.
+func (r *RasaCtl) Endpoints() error {
+	var endpoints *rtypes.EndpointsFile
+	var err error
+	if r.IsDockerBackend() {
+		endpoints, err = r.composeEndpoints()
+	} else {
+		endpoints, err = r.forwardedEndpoints()
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeConfigurationFile(r.Flags.Endpoints.Output, endpoints)
+}
+
+// Credentials saves the credentials.yaml configuration that connects a Rasa server with Rasa X.
+func (r *RasaCtl) Credentials() error {
+	url := fmt.Sprintf("http://127.0.0.1:%d", r.Flags.Credentials.RasaXPort)
+	if r.IsDockerBackend() {
+		state, err := r.DockerClient.ReadComposeState(r.Namespace)
+		if err != nil {
+			return err
+		}
+		url = state.Spec.RasaXURL()
+	}
+
+	return writeConfigurationFile(r.Flags.Credentials.Output, rasaCredentials(url))
+}
+
+// forwardedEndpoints returns configuration for services of the deployment available on forwarded local ports.
+func (r *RasaCtl) forwardedEndpoints() (*rtypes.EndpointsFile, error) {
+	flags := r.Flags.Endpoints
+
+	if err := r.GetAllHelmValues(); err != nil {
+		return nil, err
+	}
+
+	endpoints, err := r.rasaEndpoints(fmt.Sprintf("http://127.0.0.1:%d", flags.RasaXPort),
+		int32(flags.PostgreSQLPort), int32(flags.RabbitMQPort))
+	if err != nil {
+		return nil, err
+	}
+
+	if r.isComponentInstalled("redis") {
+		password, err := r.KubernetesClient.GetRedisPassword()
+		if err != nil {
+			return nil, err
+		}
+		endpoints.LockStore = redisLockStore(flags.RedisPort, password)
+	}
+
+	if r.isComponentInstalled("app") {
+		endpoints.ActionEndpoint = localActionEndpoint(flags.ActionServerPort)
+	} else if app, ok := r.HelmClient.GetValues()["app"].(map[string]interface{}); ok {
+		// The deployment uses an external action server.
+		if url, ok := app["existingUrl"].(string); ok && url != "" {
+			endpoints.ActionEndpoint = &rtypes.EndpointActionSpec{URL: url}
+		}
+	}
+
+	return endpoints, nil
+}
+
+// composeEndpoints returns configuration for services of a deployment that uses the Docker backend.
+// The action endpoint points to an action server that runs on the local machine, e.g. with the connect actions command.
+func (r *RasaCtl) composeEndpoints() (*rtypes.EndpointsFile, error) {
+	endpoints, err := r.composeRasaEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.DockerClient.ReadComposeState(r.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Spec.RedisPort != 0 {
+		endpoints.LockStore = redisLockStore(state.Spec.RedisPort, state.Spec.RedisPassword)
+	}
+	endpoints.ActionEndpoint = localActionEndpoint(r.Flags.Endpoints.ActionServerPort)
+
+	return endpoints, nil
+}
+
+func redisLockStore(port int, password string) *rtypes.EndpointLockStoreSpec {
+	return &rtypes.EndpointLockStoreSpec{
+		Type:     "redis",
+		URL:      "127.0.0.1",
+		Port:     int32(port),
+		Password: password,
+		Db:       lockStoreDb,
+	}
+}
+
+func localActionEndpoint(port int) *rtypes.EndpointActionSpec {
+	return &rtypes.EndpointActionSpec{URL: fmt.Sprintf("http://127.0.0.1:%d/webhook", port)}
+}
+
+// rasaCredentials returns the credentials.yaml configuration for a given Rasa X URL.
+func rasaCredentials(url string) *rtypes.CredentialsFile {
+	creds := &rtypes.CredentialsFile{}
+	creds.Rasa.URL = fmt.Sprintf("%s/api", url)
+
+	return creds
+}
+
+// writeConfigurationFile saves a given configuration as YAML, it's printed to stdout if the file is empty.
+func writeConfigurationFile(file string, config interface{}) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
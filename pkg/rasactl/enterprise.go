@@ -17,14 +17,23 @@ package rasactl
 
 import (
 	"fmt"
+	"os"
 
 	"golang.org/x/xerrors"
 
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
 	"github.com/RasaHQ/rasactl/pkg/utils"
 )
 
 // EnterpriseActivate activates an Enterprise license.
 func (r *RasaCtl) EnterpriseActivate() error {
+	return r.enterpriseActivate(func() (string, error) {
+		return utils.ReadLicense(r.Flags)
+	})
+}
+
+func (r *RasaCtl) enterpriseActivate(readLicense func() (string, error)) error {
 	r.initRasaXClient()
 
 	version, err := r.RasaXClient.GetVersionEndpoint()
@@ -47,7 +56,7 @@ func (r *RasaCtl) EnterpriseActivate() error {
 	}
 	r.RasaXClient.BearerToken = token
 
-	license, err := utils.ReadLicense(r.Flags)
+	license, err := readLicense()
 	if err != nil {
 		return err
 	}
@@ -80,3 +89,36 @@ func (r *RasaCtl) EnterpriseDeactivate() error {
 
 	return r.RasaXClient.EnterpriseDeactivate()
 }
+
+// EnterpriseStatus prints the state of an Enterprise license.
+func (r *RasaCtl) EnterpriseStatus() error {
+	r.initRasaXClient()
+
+	version, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		return err
+	}
+
+	state := types.EnterpriseStatusOutput{
+		Deployment:   r.Namespace,
+		Active:       version.Enterprise,
+		RasaXVersion: version.RasaX,
+	}
+
+	if !status.IsTableOutput(r.Flags.Enterprise.Status.Output) {
+		return status.FprintObject(os.Stdout, state, r.Flags.Enterprise.Status.Output)
+	}
+
+	enterprise := "inactive"
+	if state.Active {
+		enterprise = "active"
+	}
+
+	status.PrintTableNoHeader([][]string{
+		{"Deployment:", state.Deployment},
+		{"Enterprise:", enterprise},
+		{"Rasa X version:", state.RasaXVersion},
+	})
+
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+)
+
+// History prints revisions of the helm release used by a given deployment.
+func (r *RasaCtl) History() error {
+	releases, err := r.HelmClient.History(r.Flags.History.Max)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	header := []string{"Revision", "Updated", "Status", "Chart", "App version", "Description"}
+
+	for _, release := range releases {
+		chart := "MISSING"
+		appVersion := "MISSING"
+		if release.Chart != nil && release.Chart.Metadata != nil {
+			chart = fmt.Sprintf("%s-%s", release.Chart.Metadata.Name, release.Chart.Metadata.Version)
+			appVersion = release.Chart.Metadata.AppVersion
+		}
+
+		data = append(data, []string{
+			strconv.Itoa(release.Version),
+			release.Info.LastDeployed.Format("Mon Jan _2 15:04:05 2006"),
+			release.Info.Status.String(),
+			chart,
+			appVersion,
+			release.Info.Description,
+		})
+	}
+
+	status.PrintTable(
+		header,
+		data,
+	)
+	return nil
+}
+
+// Rollback rolls back a given deployment to a previous revision of the helm release.
+func (r *RasaCtl) Rollback() error {
+	revision := r.Flags.Rollback.Revision
+
+	msg := "Rolling back to the previous revision"
+	if revision != 0 {
+		msg = fmt.Sprintf("Rolling back to revision %d", revision)
+	}
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "namespace", r.Namespace)
+
+	// Helm rolls back to the revision before the current one if a revision is not defined.
+	target := revision
+	if target == 0 {
+		currentRelease, err := r.HelmClient.GetStatus()
+		if err != nil {
+			return err
+		}
+		target = currentRelease.Version - 1
+	}
+
+	if err := r.HelmClient.Rollback(revision); err != nil {
+		return err
+	}
+
+	r.initRasaXClient()
+
+	url, err := r.GetRasaXURL()
+	if err != nil {
+		return err
+	}
+	r.RasaXClient.URL = url
+
+	if err := r.RasaXClient.WaitForRasaX(); err != nil {
+		return err
+	}
+
+	rasaXVersion, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		return err
+	}
+
+	helmRelease, err := r.HelmClient.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	if err := r.KubernetesClient.UpdateSecretWithState(rasaXVersion, helmRelease); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The %s deployment has been rolled back to revision %d, new revision %d.\n", r.Namespace, target, helmRelease.Version)
+	return nil
+}
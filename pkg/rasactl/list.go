@@ -17,6 +17,7 @@ package rasactl
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/RasaHQ/rasactl/pkg/status"
 	"github.com/RasaHQ/rasactl/pkg/types"
@@ -24,14 +25,13 @@ import (
 
 // List lists all deployments.
 func (r *RasaCtl) List() error {
-	data := [][]string{}
-	header := []string{"Current", "Name", "Status", "Rasa production", "Rasa worker", "Enterprise", "Version"}
+	list := types.DeploymentListOutput{Deployments: []types.DeploymentListItemOutput{}}
 	namespaces, err := r.KubernetesClient.GetNamespaces()
 	if err != nil {
 		return err
 	}
 
-	if len(namespaces) == 0 {
+	if len(namespaces) == 0 && status.IsTableOutput(r.Flags.List.Output) {
 		fmt.Println("Nothing to show, use the start command to create a new deployment.")
 		return nil
 	}
@@ -53,50 +53,60 @@ func (r *RasaCtl) List() error {
 			return err
 		}
 
-		current := ""
-		if namespace == r.Namespace {
-			current = "*"
+		deployment := types.DeploymentListItemOutput{
+			Current:               namespace == r.Namespace,
+			Name:                  namespace,
+			Status:                status,
+			RasaProductionVersion: "0.0.0",
+			RasaWorkerVersion:     "0.0.0",
+			Enterprise:            string(stateData[types.StateEnterprise]),
+			Version:               string(stateData[types.StateRasaXVersion]),
 		}
 
 		r.initRasaXClient()
 
 		versionEndpoint, err := r.RasaXClient.GetVersionEndpoint()
 		if err == nil {
-			enterprise := "inactive"
+			deployment.Enterprise = "inactive"
 			if versionEndpoint.Enterprise {
-				enterprise = "active"
+				deployment.Enterprise = "active"
 			}
 
-			rasaProductionVersion := versionEndpoint.Rasa.Production
-			if versionEndpoint.Rasa.Production == "" {
-				rasaProductionVersion = "0.0.0"
+			if versionEndpoint.Rasa.Production != "" {
+				deployment.RasaProductionVersion = versionEndpoint.Rasa.Production
 			}
 
-			rasaWorkerVersion := versionEndpoint.Rasa.Worker
-			if versionEndpoint.Rasa.Worker == "" {
-				rasaWorkerVersion = "0.0.0"
+			if versionEndpoint.Rasa.Worker != "" {
+				deployment.RasaWorkerVersion = versionEndpoint.Rasa.Worker
 			}
 
-			data = append(data, []string{current, namespace, status,
-				rasaProductionVersion,
-				rasaWorkerVersion,
-				enterprise,
-				versionEndpoint.RasaX,
-			},
-			)
-		} else {
-			data = append(data, []string{current, namespace, status,
-				"0.0.0",
-				"0.0.0",
-				string(stateData[types.StateEnterprise]),
-				string(stateData[types.StateRasaXVersion]),
-			},
-			)
+			deployment.Version = versionEndpoint.RasaX
 		}
+
+		list.Deployments = append(list.Deployments, deployment)
+	}
+
+	if !status.IsTableOutput(r.Flags.List.Output) {
+		return status.FprintObject(os.Stdout, list, r.Flags.List.Output)
+	}
+
+	data := [][]string{}
+	for _, deployment := range list.Deployments {
+		current := ""
+		if deployment.Current {
+			current = "*"
+		}
+
+		data = append(data, []string{current, deployment.Name, deployment.Status,
+			deployment.RasaProductionVersion,
+			deployment.RasaWorkerVersion,
+			deployment.Enterprise,
+			deployment.Version,
+		})
 	}
 
 	status.PrintTable(
-		header,
+		[]string{"Current", "Name", "Status", "Rasa production", "Rasa worker", "Enterprise", "Version"},
 		data,
 	)
 	return nil
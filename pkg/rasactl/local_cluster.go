@@ -0,0 +1,53 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+// initLocalCluster detects if the current Kubernetes context is a local cluster
+// supported by rasactl and initializes the r.LocalCluster backend.
+func (r *RasaCtl) initLocalCluster(dockerClient *docker.Docker) error {
+	node, err := r.KubernetesClient.GetControlPlaneNode()
+	if err != nil {
+		return err
+	}
+
+	clusterType := docker.DetectLocalClusterType(node)
+	if clusterType == types.LocalClusterUnknown {
+		r.Log.Info("Can't find a local cluster. Are you sure that the current Kubernetes context is kind, k3d or minikube?")
+		return nil
+	}
+
+	r.LocalCluster = docker.NewLocalCluster(dockerClient,
+		docker.LocalClusterSpec{
+			Type:             clusterType,
+			ControlPlaneHost: node.Name,
+			Version:          node.Status.NodeInfo.KubeletVersion,
+			Labels:           node.Labels,
+		},
+	)
+
+	return nil
+}
+
+// isLocalCluster returns true if the current Kubernetes context is a local cluster
+// supported by rasactl.
+func (r *RasaCtl) isLocalCluster() bool {
+	return r.LocalCluster != nil
+}
@@ -16,18 +16,38 @@ limitations under the License.
 package rasactl
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/terminal"
+	"golang.org/x/xerrors"
 )
 
+// Logs prints logs for a container in a pod. If the --all or --selector flag is used,
+// logs from all matching pods and containers are merged into one stream.
 func (r *RasaCtl) Logs(args []string) error {
+	if r.IsDockerBackend() {
+		return r.composeLogs(args)
+	}
+
 	pod := ""
 
+	grep, err := compileLogsGrep(r.Flags.Logs.Grep)
+	if err != nil {
+		return err
+	}
+
+	if r.Flags.Logs.All || r.Flags.Logs.Selector != "" {
+		return r.logsMultiplePods(grep)
+	}
+
 	surveyIconsOpts := survey.WithIcons(func(icons *survey.IconSet) {
 		icons.Question.Text = ""
 		icons.Help.Format = "magenta"
@@ -96,23 +116,44 @@ func (r *RasaCtl) Logs(args []string) error {
 	}
 	defer stream.Close()
 
-	for {
-		buf := make([]byte, 2000)
-		numBytes, err := stream.Read(buf)
+	return printLogLines(stream, os.Stdout, "", grep)
+}
+
+// compileLogsGrep compiles a regular expression used to filter log lines.
+func compileLogsGrep(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	grep, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid --grep expression: %s", err)
+	}
+
+	return grep, nil
+}
 
-		if numBytes == 0 {
-			break
+// printLogLines copies log lines from a stream to a given writer. Each line is prefixed with a given prefix,
+// and lines that don't match the grep expression are skipped.
+func printLogLines(stream io.Reader, w io.Writer, prefix string, grep *regexp.Regexp) error {
+	reader := bufio.NewReader(stream)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" && (grep == nil || grep.MatchString(line)) {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			if _, err := fmt.Fprint(w, prefix+line); err != nil {
+				return err
+			}
 		}
 
 		if errors.Is(err, io.EOF) {
-			break
+			return nil
 		}
 		if err != nil {
 			return err
 		}
-		msg := string(buf[:numBytes])
-		fmt.Print(msg)
 	}
-
-	return nil
 }
@@ -0,0 +1,262 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// logsPrefixColors is a list of colors used for pod/container prefixes.
+var logsPrefixColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+	color.FgHiCyan,
+	color.FgHiGreen,
+	color.FgHiYellow,
+	color.FgHiBlue,
+	color.FgHiMagenta,
+}
+
+// syncWriter serializes writes from many log streams.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// multiLogs merges log streams from many pods and containers.
+type multiLogs struct {
+	r        *RasaCtl
+	selector labels.Selector
+	grep     *regexp.Regexp
+	out      io.Writer
+	started  time.Time
+
+	mu sync.Mutex
+	// active stores containers that are currently streamed.
+	active map[string]bool
+	// finished stores the time when a stream for a container ended,
+	// it's used to continue streaming after a container restart.
+	finished map[string]time.Time
+
+	wg sync.WaitGroup
+}
+
+// logsMultiplePods prints logs from all pods that match the label selector. Each line is prefixed
+// with a pod and container name. If the --follow flag is used, pods are watched and
+// logs of pods created or restarted later are streamed as well.
+func (r *RasaCtl) logsMultiplePods(grep *regexp.Regexp) error {
+	selector, err := labels.Parse(r.Flags.Logs.Selector)
+	if err != nil {
+		return err
+	}
+
+	m := &multiLogs{
+		r:        r,
+		selector: selector,
+		grep:     grep,
+		out:      &syncWriter{w: os.Stdout},
+		started:  time.Now(),
+		active:   map[string]bool{},
+		finished: map[string]time.Time{},
+	}
+
+	if r.Flags.Logs.Follow {
+		return m.follow(context.Background())
+	}
+
+	pods, err := r.KubernetesClient.GetPods()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range pods.Items {
+		if m.matches(&pods.Items[i]) {
+			found = true
+			m.streamPod(context.Background(), &pods.Items[i])
+		}
+	}
+	m.wg.Wait()
+
+	if !found {
+		fmt.Println("No pods match the given selector.")
+	}
+
+	return nil
+}
+
+// follow watches pods and starts streaming logs for running containers until a given context is canceled.
+func (m *multiLogs) follow(ctx context.Context) error {
+	for {
+		w, err := m.r.KubernetesClient.WatchPods(ctx)
+		if err != nil {
+			return err
+		}
+
+		for event := range w.ResultChan() {
+			if event.Type != watch.Added && event.Type != watch.Modified {
+				continue
+			}
+
+			pod, ok := event.Object.(*v1.Pod)
+			if !ok || !m.matches(pod) {
+				continue
+			}
+			m.streamPod(ctx, pod)
+		}
+		w.Stop()
+
+		// The API server closes watches after a timeout, start a new one.
+		select {
+		case <-ctx.Done():
+			m.wg.Wait()
+			return nil
+		default:
+			m.r.Log.V(1).Info("Restarting the pods watch")
+		}
+	}
+}
+
+func (m *multiLogs) matches(pod *v1.Pod) bool {
+	return m.selector.Matches(labels.Set(pod.Labels))
+}
+
+// streamPod starts streaming logs for containers of a given pod that are not streamed yet.
+func (m *multiLogs) streamPod(ctx context.Context, pod *v1.Pod) {
+	for _, container := range pod.Spec.Containers {
+		if m.r.Flags.Logs.Container != "" && container.Name != m.r.Flags.Logs.Container {
+			continue
+		}
+
+		// Logs are available only for containers that have started.
+		if m.r.Flags.Logs.Follow && !isContainerRunning(pod, container.Name) {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", pod.Name, container.Name)
+
+		m.mu.Lock()
+		if m.active[key] {
+			m.mu.Unlock()
+			continue
+		}
+		m.active[key] = true
+		opts := m.logOptions(pod, key, container.Name)
+		m.mu.Unlock()
+
+		m.wg.Add(1)
+		go func(pod, container, key string) {
+			defer m.wg.Done()
+
+			prefix := logsPrefix(pod, container)
+			if err := m.stream(ctx, pod, prefix, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "%s%s\n", prefix, err)
+			}
+
+			m.mu.Lock()
+			delete(m.active, key)
+			m.finished[key] = time.Now()
+			m.mu.Unlock()
+		}(pod.Name, container.Name, key)
+	}
+}
+
+func (m *multiLogs) stream(ctx context.Context, pod, prefix string, opts *v1.PodLogOptions) error {
+	stream, err := m.r.KubernetesClient.GetPodLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	return printLogLines(stream, m.out, prefix, m.grep)
+}
+
+// logOptions returns log options for a given container. The --tail and --since flags apply
+// to containers that existed when the command started, logs of containers
+// restarted or created later are streamed from the beginning.
+func (m *multiLogs) logOptions(pod *v1.Pod, key, container string) *v1.PodLogOptions {
+	flags := m.r.Flags.Logs
+	opts := &v1.PodLogOptions{
+		Container:  container,
+		Follow:     flags.Follow,
+		Previous:   flags.Previous,
+		Timestamps: flags.Timestamps,
+	}
+
+	if finished, ok := m.finished[key]; ok {
+		sinceTime := metav1.NewTime(finished)
+		opts.SinceTime = &sinceTime
+		return opts
+	}
+
+	if pod.CreationTimestamp.After(m.started) {
+		return opts
+	}
+
+	if flags.TailLines > 0 {
+		tailLines := flags.TailLines
+		opts.TailLines = &tailLines
+	}
+
+	if flags.Since > 0 {
+		sinceSeconds := int64(flags.Since.Seconds())
+		opts.SinceSeconds = &sinceSeconds
+	}
+
+	return opts
+}
+
+func isContainerRunning(pod *v1.Pod, container string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// logsPrefix returns a colored prefix for a given pod and container,
+// the color is the same for all containers of a pod.
+func logsPrefix(pod, container string) string {
+	hash := fnv.New32a()
+	//nolint:errcheck
+	hash.Write([]byte(pod))
+	c := color.New(logsPrefixColors[hash.Sum32()%uint32(len(logsPrefixColors))])
+
+	return c.Sprintf("[%s/%s] ", pod, container)
+}
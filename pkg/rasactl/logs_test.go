@@ -0,0 +1,179 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+func TestPrintLogLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		prefix   string
+		grep     string
+		expected string
+	}{
+		{
+			name:     "empty stream",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "all lines",
+			input:    "first\nsecond\n",
+			expected: "first\nsecond\n",
+		},
+		{
+			name:     "partial last line",
+			input:    "first\nsecond",
+			expected: "first\nsecond\n",
+		},
+		{
+			name:     "prefix",
+			input:    "first\nsecond",
+			prefix:   "[pod/container] ",
+			expected: "[pod/container] first\n[pod/container] second\n",
+		},
+		{
+			name:     "grep",
+			input:    "INFO started\nERROR failed\nINFO stopped\n",
+			grep:     "ERROR",
+			expected: "ERROR failed\n",
+		},
+		{
+			name:     "grep with prefix and partial last line",
+			input:    "INFO started\nERROR failed\nERROR again",
+			prefix:   "[pod/container] ",
+			grep:     "^ERROR",
+			expected: "[pod/container] ERROR failed\n[pod/container] ERROR again\n",
+		},
+		{
+			name:     "grep without matches",
+			input:    "INFO started\nINFO stopped\n",
+			grep:     "ERROR",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var grep *regexp.Regexp
+			if tt.grep != "" {
+				grep = regexp.MustCompile(tt.grep)
+			}
+
+			out := &bytes.Buffer{}
+			err := printLogLines(strings.NewReader(tt.input), out, tt.prefix, grep)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestLogOptions(t *testing.T) {
+	started := time.Now()
+	finished := started.Add(time.Minute)
+	existingPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "existing",
+		CreationTimestamp: metav1.NewTime(started.Add(-time.Hour)),
+	}}
+	newPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "new",
+		CreationTimestamp: metav1.NewTime(started.Add(time.Hour)),
+	}}
+	flags := types.RasaCtlLogsFlags{
+		TailLines:  10,
+		Since:      5 * time.Minute,
+		Follow:     true,
+		Timestamps: true,
+	}
+
+	tests := []struct {
+		name         string
+		pod          *v1.Pod
+		finished     map[string]time.Time
+		tailLines    *int64
+		sinceSeconds *int64
+		sinceTime    *time.Time
+	}{
+		{
+			name:         "container existing at start",
+			pod:          existingPod,
+			tailLines:    int64Ptr(10),
+			sinceSeconds: int64Ptr(300),
+		},
+		{
+			name: "container created after start",
+			pod:  newPod,
+		},
+		{
+			name:      "restarted container",
+			pod:       existingPod,
+			finished:  map[string]time.Time{"existing/rasa": finished},
+			sinceTime: &finished,
+		},
+		{
+			name:         "restart of another container",
+			pod:          existingPod,
+			finished:     map[string]time.Time{"existing/nginx": finished},
+			tailLines:    int64Ptr(10),
+			sinceSeconds: int64Ptr(300),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finishedStreams := tt.finished
+			if finishedStreams == nil {
+				finishedStreams = map[string]time.Time{}
+			}
+			m := &multiLogs{
+				r:        &RasaCtl{Flags: &types.RasaCtlFlags{Logs: flags}},
+				started:  started,
+				finished: finishedStreams,
+			}
+
+			opts := m.logOptions(tt.pod, tt.pod.Name+"/rasa", "rasa")
+			require.Equal(t, "rasa", opts.Container)
+			require.True(t, opts.Follow)
+			require.True(t, opts.Timestamps)
+			require.Equal(t, tt.tailLines, opts.TailLines)
+			require.Equal(t, tt.sinceSeconds, opts.SinceSeconds)
+
+			if tt.sinceTime == nil {
+				require.Nil(t, opts.SinceTime)
+			} else {
+				require.NotNil(t, opts.SinceTime)
+				require.True(t, tt.sinceTime.Equal(opts.SinceTime.Time))
+			}
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
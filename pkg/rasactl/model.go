@@ -18,12 +18,14 @@ package rasactl
 import (
 	"fmt"
 	"math"
+	"os"
 	"strings"
 	"time"
 
 	"golang.org/x/xerrors"
 
 	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
 )
 
 func (r *RasaCtl) checkIfRasaOSSProductionIsConnected() error {
@@ -41,7 +43,8 @@ func (r *RasaCtl) checkIfRasaOSSProductionIsConnected() error {
 	return nil
 }
 
-func (r *RasaCtl) ModelUpload() error {
+// ModelUpload uploads a given model file to Rasa X.
+func (r *RasaCtl) ModelUpload(file string, timeout time.Duration) error {
 
 	if err := r.checkIfRasaOSSProductionIsConnected(); err != nil {
 		return err
@@ -53,7 +56,7 @@ func (r *RasaCtl) ModelUpload() error {
 	}
 	r.RasaXClient.BearerToken = token
 
-	return r.RasaXClient.ModelUpload()
+	return r.RasaXClient.ModelUpload(file, timeout)
 }
 
 func (r *RasaCtl) ModelDelete() error {
@@ -93,7 +96,8 @@ func (r *RasaCtl) ModelDownload() error {
 	return r.RasaXClient.ModelDownload()
 }
 
-func (r *RasaCtl) ModelTag() error {
+// ModelTag tags a given model.
+func (r *RasaCtl) ModelTag(model, tag string) error {
 	if err := r.checkIfRasaOSSProductionIsConnected(); err != nil {
 		return err
 	}
@@ -104,7 +108,7 @@ func (r *RasaCtl) ModelTag() error {
 	}
 	r.RasaXClient.BearerToken = token
 
-	return r.RasaXClient.ModelTag()
+	return r.RasaXClient.ModelTag(model, tag)
 }
 
 func (r *RasaCtl) ModelList() error {
@@ -126,24 +130,44 @@ func (r *RasaCtl) ModelList() error {
 		return err
 	}
 
-	if len(models.Models) == 0 {
+	list := types.ModelListOutput{Models: []types.ModelOutput{}}
+	for _, model := range models.Models {
+		sec, dec := math.Modf(model.TrainedAt)
+		tags := model.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		list.Models = append(list.Models, types.ModelOutput{
+			Name:       model.Model,
+			Version:    model.Version,
+			Compatible: model.IsCompatible,
+			Tags:       tags,
+			Hash:       model.Hash,
+			TrainedAt:  time.Unix(int64(sec), int64(dec*(1e9))).UTC(),
+		})
+	}
+
+	if !status.IsTableOutput(r.Flags.Model.List.Output) {
+		return status.FprintObject(os.Stdout, list, r.Flags.Model.List.Output)
+	}
+
+	if len(list.Models) == 0 {
 		fmt.Println("Nothing to show, upload model to see results.")
 		return nil
 	}
 
-	for _, model := range models.Models {
-		sec, dec := math.Modf(model.TrainedAt)
+	for _, model := range list.Models {
 		tags := "none"
 		if len(model.Tags) != 0 {
 			tags = strings.Join(model.Tags, ",")
 		}
 		data = append(data, []string{
-			model.Model,
+			model.Name,
 			model.Version,
-			fmt.Sprintf("%t", model.IsCompatible),
+			fmt.Sprintf("%t", model.Compatible),
 			tags,
 			model.Hash,
-			time.Unix(int64(sec), int64(dec*(1e9))).Format("02 Jan 06 15:04 MST"),
+			model.TrainedAt.Local().Format("02 Jan 06 15:04 MST"),
 		})
 	}
 	status.PrintTable(
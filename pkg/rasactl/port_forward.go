@@ -0,0 +1,181 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+)
+
+// forwardedPorts stores local ports forwarded to services of a deployment.
+// The redis and actionServer ports are 0 if the components are not installed.
+type forwardedPorts struct {
+	rasaX        uint16
+	postgreSQL   uint16
+	rabbitMQ     uint16
+	redis        uint16
+	actionServer uint16
+}
+
+func (p *forwardedPorts) rasaXURL() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", p.rasaX)
+}
+
+// endpointsCommand returns the 'rasactl endpoints' command that uses the forwarded ports.
+func (p *forwardedPorts) endpointsCommand(deployment string) string {
+	args := []string{
+		"rasactl", "endpoints", deployment,
+		fmt.Sprintf("--rasa-x-port %d", p.rasaX),
+		fmt.Sprintf("--postgresql-port %d", p.postgreSQL),
+		fmt.Sprintf("--rabbitmq-port %d", p.rabbitMQ),
+	}
+	if p.redis != 0 {
+		args = append(args, fmt.Sprintf("--redis-port %d", p.redis))
+	}
+	if p.actionServer != 0 {
+		args = append(args, fmt.Sprintf("--action-server-port %d", p.actionServer))
+	}
+	return strings.Join(args, " ")
+}
+
+// PortForward forwards local ports to the Rasa X, PostgreSQL, RabbitMQ, Redis,
+// and action server services of a deployment until the process is interrupted.
+func (r *RasaCtl) PortForward() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r.Spinner.Message("Forwarding ports")
+	if err := r.startPortForwarding(ctx); err != nil {
+		return err
+	}
+	r.Spinner.Stop()
+
+	data := [][]string{
+		{"rasa-x", r.forwardedPorts.rasaXURL()},
+		{"postgresql", fmt.Sprintf("127.0.0.1:%d", r.forwardedPorts.postgreSQL)},
+		{"rabbitmq", fmt.Sprintf("127.0.0.1:%d", r.forwardedPorts.rabbitMQ)},
+	}
+	if r.forwardedPorts.redis != 0 {
+		data = append(data, []string{"redis", fmt.Sprintf("127.0.0.1:%d", r.forwardedPorts.redis)})
+	}
+	if r.forwardedPorts.actionServer != 0 {
+		data = append(data, []string{"app", fmt.Sprintf("http://127.0.0.1:%d", r.forwardedPorts.actionServer)})
+	}
+	status.PrintTable([]string{"Service", "Address"}, data)
+	fmt.Printf("Use '%s' to generate the endpoints configuration.\n", r.forwardedPorts.endpointsCommand(r.Namespace))
+	fmt.Println("Forwarding ports, press Ctrl+C to stop")
+
+	<-ctx.Done()
+	return nil
+}
+
+// startPortForwarding forwards local ports to the Rasa X, PostgreSQL, RabbitMQ, Redis, and action server services.
+// Redis and the action server are skipped if they are not installed by the helm chart.
+// The ports are forwarded until the context is done, a random local port is used if a given port is already in use.
+func (r *RasaCtl) startPortForwarding(ctx context.Context) error {
+	if err := r.GetAllHelmValues(); err != nil {
+		return err
+	}
+	releaseName := r.HelmClient.GetConfiguration().ReleaseName
+
+	rasaXService, err := r.KubernetesClient.GetRasaXService()
+	if err != nil {
+		return err
+	}
+
+	ports := &forwardedPorts{}
+	ports.rasaX, err = r.KubernetesClient.PortForward(ctx, rasaXService.Name, 0, r.localPort("rasa-x", r.Flags.PortForward.RasaXPort))
+	if err != nil {
+		return err
+	}
+
+	ports.postgreSQL, err = r.KubernetesClient.PortForward(ctx,
+		fmt.Sprintf("%s-postgresql", releaseName), 0, r.localPort("postgresql", r.Flags.PortForward.PostgreSQLPort))
+	if err != nil {
+		return err
+	}
+
+	ports.rabbitMQ, err = r.KubernetesClient.PortForward(ctx,
+		fmt.Sprintf("%s-rabbit", releaseName), r.rabbitMQServicePort(), r.localPort("rabbitmq", r.Flags.PortForward.RabbitMQPort))
+	if err != nil {
+		return err
+	}
+
+	if r.isComponentInstalled("redis") {
+		ports.redis, err = r.KubernetesClient.PortForward(ctx,
+			fmt.Sprintf("%s-redis-master", releaseName), 0, r.localPort("redis", r.Flags.PortForward.RedisPort))
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.isComponentInstalled("app") {
+		ports.actionServer, err = r.KubernetesClient.PortForward(ctx,
+			fmt.Sprintf("%s-app", releaseName), 0, r.localPort("app", r.Flags.PortForward.ActionServerPort))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("Ports have been forwarded",
+		"rasa-x", ports.rasaX, "postgresql", ports.postgreSQL, "rabbitmq", ports.rabbitMQ,
+		"redis", ports.redis, "app", ports.actionServer)
+	r.forwardedPorts = ports
+	return nil
+}
+
+// localPort returns a given local port, or 0 if the port can't be used, e.g. it's already in use.
+// A random local port is forwarded if the port is 0.
+func (r *RasaCtl) localPort(service string, port int) int {
+	if port == 0 {
+		return 0
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		r.Log.Info("Can't use a local port, a random port is used instead", "service", service, "port", port, "error", err)
+		return 0
+	}
+	defer listener.Close()
+
+	return port
+}
+
+// rabbitMQServicePort returns the AMQP port of the RabbitMQ service.
+func (r *RasaCtl) rabbitMQServicePort() int32 {
+	if rabbitmq, ok := r.HelmClient.GetValues()["rabbitmq"].(map[string]interface{}); ok {
+		if service, ok := rabbitmq["service"].(map[string]interface{}); ok {
+			if port, ok := service["port"].(float64); ok {
+				return int32(port)
+			}
+		}
+	}
+	return 5672
+}
+
+// isComponentInstalled checks if a given component, e.g. redis, is installed by the helm chart.
+func (r *RasaCtl) isComponentInstalled(name string) bool {
+	if component, ok := r.HelmClient.GetValues()[name].(map[string]interface{}); ok {
+		if install, ok := component["install"].(bool); ok {
+			return install
+		}
+	}
+	return true
+}
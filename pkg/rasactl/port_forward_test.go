@@ -0,0 +1,57 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"net"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalPort(t *testing.T) {
+	r := &RasaCtl{Log: logr.Discard()}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	// A port that is in use is replaced by a random port.
+	require.Equal(t, 0, r.localPort("rasa-x", port))
+
+	// A free port is used as it is.
+	require.NoError(t, listener.Close())
+	require.Equal(t, port, r.localPort("rasa-x", port))
+
+	require.Equal(t, 0, r.localPort("rasa-x", 0))
+}
+
+func TestEndpointsCommand(t *testing.T) {
+	ports := &forwardedPorts{rasaX: 5002, postgreSQL: 41234, rabbitMQ: 41235}
+	require.Equal(t,
+		"rasactl endpoints my-deployment --rasa-x-port 5002 --postgresql-port 41234 --rabbitmq-port 41235",
+		ports.endpointsCommand("my-deployment"),
+	)
+
+	ports.redis = 41236
+	ports.actionServer = 41237
+	require.Equal(t,
+		"rasactl endpoints my-deployment --rasa-x-port 5002 --postgresql-port 41234 --rabbitmq-port 41235 "+
+			"--redis-port 41236 --action-server-port 41237",
+		ports.endpointsCommand("my-deployment"),
+	)
+}
@@ -0,0 +1,197 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// progressInterval defines how often the readiness of pods is checked.
+	progressInterval = 3 * time.Second
+
+	// progressMessageLength is the maximum length of an event message shown in the spinner.
+	progressMessageLength = 120
+)
+
+// podWaitingProblems stores reasons for a waiting container that usually mean
+// that a deployment won't become ready without user action.
+var podWaitingProblems = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// watchDeploymentProgress reports the progress of a deployment rollout in the spinner and the verbose log.
+//
+// Namespace events are streamed, and the readiness of pods is checked periodically,
+// so that problems such as image pulls, crash loops or pending scheduling are visible before helm times out.
+// Reporting continues until the returned function is called.
+func (r *RasaCtl) watchDeploymentProgress(msg string) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		r.reportDeploymentProgress(ctx, msg)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (r *RasaCtl) reportDeploymentProgress(ctx context.Context, msg string) {
+	// Event timestamps have a precision of one second.
+	startTime := time.Now().Truncate(time.Second)
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	var events <-chan watch.Event
+	watcher, err := r.KubernetesClient.WatchEvents(ctx)
+	if err != nil {
+		r.Log.V(1).Info("Can't watch events", "namespace", r.Namespace, "error", err)
+	} else {
+		defer watcher.Stop()
+		events = watcher.ResultChan()
+	}
+
+	pods := ""
+	lastEvent := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				// The watch has been closed by the server, rely on checking pods only.
+				events = nil
+				continue
+			}
+
+			event, ok := e.Object.(*v1.Event)
+			if !ok || e.Type == watch.Deleted || eventTime(*event).Before(startTime) {
+				continue
+			}
+
+			r.Log.V(1).Info("Event", "type", event.Type, "reason", event.Reason,
+				"object", fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+				"message", event.Message)
+
+			if event.Type != v1.EventTypeWarning {
+				continue
+			}
+			lastEvent = truncateMessage(fmt.Sprintf("%s %s: %s", event.InvolvedObject.Name, event.Reason, event.Message))
+		case <-ticker.C:
+			status, err := r.podsProgress()
+			if err != nil {
+				r.Log.V(1).Info("Can't check pods", "namespace", r.Namespace, "error", err)
+				continue
+			}
+			if status == pods {
+				continue
+			}
+			pods = status
+			r.Log.V(1).Info("Rollout progress", "namespace", r.Namespace, "pods", pods)
+
+			// Problems found for pods take precedence over warning events.
+			lastEvent = ""
+		}
+
+		message := msg
+		if pods != "" {
+			message = fmt.Sprintf("%s (%s)", message, pods)
+		}
+		if lastEvent != "" {
+			message = fmt.Sprintf("%s, %s", message, lastEvent)
+		}
+		r.Spinner.Message(message)
+	}
+}
+
+// podsProgress returns a summary of the readiness of pods along with problems found for pods.
+func (r *RasaCtl) podsProgress() (string, error) {
+	pods, err := r.KubernetesClient.GetPods()
+	if err != nil {
+		return "", err
+	}
+
+	if len(pods.Items) == 0 {
+		return "", nil
+	}
+
+	ready := 0
+	problems := []string{}
+	for _, pod := range pods.Items {
+		if r.KubernetesClient.PodStatus(pod.Status.Conditions) == "Ready" && pod.Status.Phase == v1.PodRunning {
+			ready++
+			continue
+		}
+
+		if problem := podProblem(pod); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", pod.Name, problem))
+		}
+	}
+
+	status := fmt.Sprintf("%d/%d pods ready", ready, len(pods.Items))
+	if len(problems) != 0 {
+		status = truncateMessage(fmt.Sprintf("%s, %s", status, strings.Join(problems, ", ")))
+	}
+
+	return status, nil
+}
+
+// podProblem returns a reason why a given pod can't become ready, or an empty string if there is no known problem.
+func podProblem(pod v1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason == v1.PodReasonUnschedulable {
+			return "Unschedulable"
+		}
+	}
+
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.State.Waiting != nil && podWaitingProblems[status.State.Waiting.Reason] {
+			return status.State.Waiting.Reason
+		}
+	}
+
+	return ""
+}
+
+func eventTime(event v1.Event) time.Time {
+	if event.LastTimestamp.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.LastTimestamp.Time
+}
+
+func truncateMessage(msg string) string {
+	msg = strings.ReplaceAll(strings.TrimSpace(msg), "\n", " ")
+	if len(msg) > progressMessageLength {
+		return msg[:progressMessageLength-3] + "..."
+	}
+	return msg
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodProblem(t *testing.T) {
+	waiting := func(reason string) v1.ContainerStatus {
+		return v1.ContainerStatus{
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pod      v1.Pod
+		expected string
+	}{
+		{
+			name: "container in CrashLoopBackOff",
+			pod: v1.Pod{Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{waiting("ContainerCreating"), waiting("CrashLoopBackOff")},
+			}},
+			expected: "CrashLoopBackOff",
+		},
+		{
+			name: "init container in ImagePullBackOff",
+			pod: v1.Pod{Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{waiting("ImagePullBackOff")},
+			}},
+			expected: "ImagePullBackOff",
+		},
+		{
+			name: "pending pod that can't be scheduled",
+			pod: v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable},
+				},
+			}},
+			expected: "Unschedulable",
+		},
+		{
+			name: "pending pod that is being scheduled",
+			pod: v1.Pod{Status: v1.PodStatus{
+				Phase:      v1.PodPending,
+				Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse}},
+			}},
+			expected: "",
+		},
+		{
+			name: "container that is being created",
+			pod: v1.Pod{Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{waiting("ContainerCreating")},
+			}},
+			expected: "",
+		},
+		{
+			name: "running pod",
+			pod: v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{
+					{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				},
+			}},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, podProblem(test.pod))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+// RasaEnable deploys the rasa production and rasa worker deployments
+// with a Rasa version compatible with the Rasa X version of the deployment.
+func (r *RasaCtl) RasaEnable() error {
+	r.initRasaXClient()
+
+	version, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		return err
+	}
+
+	rasaVersion, err := status.CompatibleRasaVersion(version.RasaX, r.Flags.Rasa.Enable.RasaVersion)
+	if err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Deploying Rasa servers")
+	return r.upgradeRasaServer(true, rasaVersion)
+}
+
+// RasaDisable removes the rasa production and rasa worker deployments.
+func (r *RasaCtl) RasaDisable() error {
+	r.Spinner.Message("Removing Rasa servers")
+	return r.upgradeRasaServer(false, "")
+}
+
+func (r *RasaCtl) upgradeRasaServer(enabled bool, version string) error {
+	if err := r.setHelmConfigurationFromState(); err != nil {
+		return err
+	}
+
+	r.HelmClient.SetValues(utils.MergeMaps(r.HelmClient.GetValues(), helm.ValuesRasaServer(enabled, version)))
+	r.Log.V(1).Info("Upgrading configuration for Rasa X deployment", "step", "set the rasa production and rasa worker deployments",
+		"enabled", enabled, "version", version)
+
+	return r.HelmClient.Upgrade()
+}
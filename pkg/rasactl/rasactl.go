@@ -18,6 +18,8 @@ package rasactl
 import (
 	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/xerrors"
@@ -45,6 +47,10 @@ type RasaCtl struct {
 	// DockerClient defines the Docker client.
 	DockerClient docker.Interface
 
+	// LocalCluster defines a backend for a local Kubernetes cluster,
+	// it's nil if the current Kubernetes context is not a local cluster.
+	LocalCluster docker.LocalClusterInterface
+
 	// Log defines logger.
 	Log logr.Logger
 
@@ -57,15 +63,35 @@ type RasaCtl struct {
 	isRasaXRunning  bool
 	isRasaXDeployed bool
 
+	// bundleImagesFile is a path to images extracted from an air-gapped bundle.
+	bundleImagesFile string
+
+	// syncProjectPath is a path to a local project that is synced by the helper pod.
+	syncProjectPath string
+
+	// forwardedPorts stores local ports forwarded to services of a deployment, it's nil if ports are not forwarded.
+	forwardedPorts *forwardedPorts
+
 	// CloudProvider stores a type of a detected cloud provider.
 	CloudProvider *cloud.Provider
 
 	// Flags stores the command flags.
 	Flags *types.RasaCtlFlags
+
+	// WaitTimeout defines time to wait for Rasa X to be ready if a deployment is not managed by helm.
+	WaitTimeout time.Duration
+
+	// cleanups stores functions that release resources created by rasactl, e.g. containers or processes.
+	cleanups     []func(sig os.Signal)
+	cleanupsLock sync.Mutex
 }
 
 // InitClients initializes clients.
 func (r *RasaCtl) InitClients() error {
+	if r.IsDockerBackend() {
+		return r.initComposeClients()
+	}
+
 	r.Spinner = status.NewSpinner()
 
 	cloudProvider := &cloud.Provider{Log: r.Log}
@@ -100,27 +126,29 @@ func (r *RasaCtl) InitClients() error {
 	helmClient.SetKubernetesBackendType(r.KubernetesClient.GetBackendType())
 	r.HelmClient = helmClient
 
-	dockerClient, err := docker.New(
-		&docker.Docker{
-			Namespace: r.Namespace,
-			Log:       r.Log,
-			Spinner:   r.Spinner,
-			Flags:     r.Flags,
-		},
-	)
+	dockerSpec := &docker.Docker{
+		Namespace: r.Namespace,
+		Log:       r.Log,
+		Spinner:   r.Spinner,
+		Flags:     r.Flags,
+	}
+	dockerClient, err := docker.New(dockerSpec)
 	if err != nil {
 		return err
 	}
 	r.DockerClient = dockerClient
 
-	return r.GetKindControlPlaneNodeInfo()
+	return r.initLocalCluster(dockerSpec)
 }
 
 // SetNamespaceClients sets namespace for initialized clients.
 func (r *RasaCtl) SetNamespaceClients(namespace string) error {
 	r.Log.V(1).Info("Setting namespace for clients", "namespace", namespace)
-	r.KubernetesClient.SetNamespace(namespace)
 	r.DockerClient.SetNamespace(namespace)
+	if r.IsDockerBackend() {
+		return nil
+	}
+	r.KubernetesClient.SetNamespace(namespace)
 
 	err := r.HelmClient.SetNamespace(namespace)
 	return err
@@ -131,6 +159,10 @@ func (r *RasaCtl) SetNamespaceClients(namespace string) error {
 // It returns 'true' as the first value if the deployment is deployed, and 'true'
 // as the second value if the deployment is running.
 func (r *RasaCtl) CheckDeploymentStatus() (bool, bool, error) {
+	if r.IsDockerBackend() {
+		return r.composeCheckDeploymentStatus()
+	}
+
 	// Check if a Rasa X deployment is already installed and running
 	isRasaXDeployed, err := r.HelmClient.IsDeployed()
 	if err != nil {
@@ -150,30 +182,29 @@ func (r *RasaCtl) CheckDeploymentStatus() (bool, bool, error) {
 
 func (r *RasaCtl) useProject(projectPath string) error {
 	if projectPath != "" || r.Flags.Start.Project {
-		if r.DockerClient.GetKind().ControlPlaneHost != "" {
-			if !r.Flags.Start.Project {
-				// check if the project path exists
-				if path, err := os.Stat(projectPath); err != nil {
-					if os.IsNotExist(err) {
-						return err
-					} else if !path.IsDir() {
-						return xerrors.Errorf("The %s path can't point to a file, it has to be a directory", projectPath)
-					}
-					return err
-				}
-				r.DockerClient.SetProjectPath(projectPath)
-			} else {
-				// use a current working directory
-				wd, err := os.Getwd()
-				if err != nil {
-					return err
-				}
-				r.DockerClient.SetProjectPath(wd)
-				projectPath = wd
+		if !r.Flags.Start.Project {
+			// check if the project path exists
+			path, err := os.Stat(projectPath)
+			if err != nil {
+				return err
 			}
+			if !path.IsDir() {
+				return xerrors.Errorf("The %s path can't point to a file, it has to be a directory", projectPath)
+			}
+		} else {
+			// use a current working directory
+			wd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			projectPath = wd
+		}
 
-			r.Spinner.Message("Creating and joining a kind node")
-			if err := r.CreateAndJoinKindNode(); err != nil {
+		if r.isLocalCluster() {
+			r.DockerClient.SetProjectPath(projectPath)
+
+			r.Spinner.Message(fmt.Sprintf("Creating and joining a %s node", r.LocalCluster.GetType()))
+			if err := r.LocalCluster.CreateNode(); err != nil {
 				return err
 			}
 			volume, err := r.KubernetesClient.CreateVolume(projectPath)
@@ -181,9 +212,13 @@ func (r *RasaCtl) useProject(projectPath string) error {
 				return err
 			}
 			r.HelmClient.SetPersistanceVolumeClaimName(volume)
+			r.HelmClient.SetUseDedicatedNode(r.LocalCluster.GetNodeName() != "")
 
 		} else {
-			return xerrors.Errorf("It looks like you don't use kind as a current Kubernetes context, the project-path flag is supported only with kind")
+			// A host path can't be used with a remote cluster, the project is synced by the helper pod.
+			if err := r.useProjectSync(projectPath); err != nil {
+				return err
+			}
 		}
 
 		if err := r.writeStatusFile(projectPath); err != nil {
@@ -208,18 +243,36 @@ func (r *RasaCtl) start() error {
 	r.Spinner.Message(msg)
 	r.Log.Info(msg)
 
-	if string(state[types.StateProjectPath]) != "" && r.DockerClient.GetKind().ControlPlaneHost != "" {
-		nodeName := fmt.Sprintf("kind-%s", r.Namespace)
-		if err := r.DockerClient.StartKindNode(nodeName); err != nil {
+	if string(state[types.StateProjectPath]) != "" && r.isLocalCluster() {
+		r.DockerClient.SetProjectPath(string(state[types.StateProjectPath]))
+		if err := r.LocalCluster.StartNode(); err != nil {
 			return err
 		}
 	}
+
+	// The rasa-x pod is scheduled on the same node as the sync pod, make sure that it's running.
+	// Files could be changed while the deployment was stopped, the project is synced again.
+	if projectPath := string(state[types.StateProjectPath]); r.isProjectSynced(projectPath) {
+		if err := r.startSyncPod(); err != nil {
+			return err
+		}
+
+		r.Spinner.Message("Syncing the project")
+		if err := r.uploadProjectFiles(projectPath, []string{"."}); err != nil {
+			return err
+		}
+		r.syncProjectPath = projectPath
+		r.Spinner.Message(msg)
+	}
 	// Set configuration used for starting a stopped project.
 	helmConfig := r.HelmClient.GetConfiguration()
 	helmConfig.StartProject = true
 	r.HelmClient.SetConfiguration(helmConfig)
 
-	if err := r.HelmClient.Upgrade(); err != nil {
+	stopProgress := r.watchDeploymentProgress(msg)
+	err = r.HelmClient.Upgrade()
+	stopProgress()
+	if err != nil {
 		return err
 	}
 
@@ -236,9 +289,18 @@ func (r *RasaCtl) startOrInstall() error {
 			return err
 		}
 
+		// loads images into kind nodes if a deployment uses an air-gapped bundle
+		if err := r.loadBundleImages(); err != nil {
+			return err
+		}
+
 		r.Spinner.Message("Deploying Rasa X")
-		if err := r.HelmClient.Install(); err != nil {
-			return helm.ErrorTimeoutWaitForCondition(err)
+		stopProgress := r.watchDeploymentProgress("Deploying Rasa X")
+		err := r.HelmClient.Install()
+		stopProgress()
+		if err != nil {
+			return xerrors.Errorf("%w\nUse 'rasactl support-bundle %s' to collect diagnostic information about the deployment",
+				helm.ErrorTimeoutWaitForCondition(err), r.Namespace)
 		}
 	} else if !r.isRasaXRunning {
 		// starts a stopped deployment
@@ -264,6 +326,18 @@ func (r *RasaCtl) GetAllHelmValues() error {
 
 // GetRasaXURL returns a Rasa X URL.
 func (r *RasaCtl) GetRasaXURL() (string, error) {
+	if r.IsDockerBackend() {
+		state, err := r.DockerClient.ReadComposeState(r.Namespace)
+		if err != nil {
+			return "", err
+		}
+		return state.Spec.RasaXURL(), nil
+	}
+
+	if r.forwardedPorts != nil {
+		return r.forwardedPorts.rasaXURL(), nil
+	}
+
 	if err := r.GetAllHelmValues(); err != nil {
 		return "", err
 	}
@@ -278,6 +352,13 @@ func (r *RasaCtl) GetRasaXURL() (string, error) {
 }
 
 func (r *RasaCtl) GetRasaXToken() (string, error) {
+	if r.IsDockerBackend() {
+		state, err := r.DockerClient.ReadComposeState(r.Namespace)
+		if err != nil {
+			return "", err
+		}
+		return state.Spec.RasaXToken, nil
+	}
 	return r.KubernetesClient.GetRasaXToken()
 }
 
@@ -287,9 +368,12 @@ func (r *RasaCtl) initRasaXClient() {
 	r.RasaXClient = &rasax.RasaX{
 		Log:            r.Log,
 		SpinnerMessage: r.Spinner,
-		WaitTimeout:    r.HelmClient.GetConfiguration().Timeout,
+		WaitTimeout:    r.WaitTimeout,
 		Flags:          r.Flags,
 	}
+	if r.HelmClient != nil {
+		r.RasaXClient.WaitTimeout = r.HelmClient.GetConfiguration().Timeout
+	}
 	r.RasaXClient.New()
 	r.RasaXClient.URL = url
 }
@@ -328,3 +412,24 @@ func (r *RasaCtl) checkDeploymentStatus() error {
 	}
 	return nil
 }
+
+// addCleanup registers a function that is executed by Cleanup.
+func (r *RasaCtl) addCleanup(f func(sig os.Signal)) {
+	r.cleanupsLock.Lock()
+	defer r.cleanupsLock.Unlock()
+
+	r.cleanups = append(r.cleanups, f)
+}
+
+// Cleanup releases resources created by the current command, e.g. it stops Rasa servers run by rasactl.
+// It's executed before rasactl exits, sig is the signal that stops rasactl or nil if a command has finished.
+// Registered functions are executed in reverse order, and only once.
+func (r *RasaCtl) Cleanup(sig os.Signal) {
+	r.cleanupsLock.Lock()
+	defer r.cleanupsLock.Unlock()
+
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i](sig)
+	}
+	r.cleanups = nil
+}
@@ -15,10 +15,35 @@ limitations under the License.
 */
 package rasactl
 
-import "github.com/RasaHQ/rasactl/pkg/utils"
+import (
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
 
 // Start starts a Rasa X / Enterprise deployment.
+//
+// If a project is synced with a remote cluster, only the current files are synced,
+// use WatchSyncedProject to keep syncing changes.
 func (r *RasaCtl) Start() error {
+	if r.IsDockerBackend() {
+		if r.Flags.Start.Bundle != "" || r.Flags.Start.WithRasaServer || r.isDryRun() {
+			return xerrors.Errorf("The --bundle, --with-rasa-server, --dry-run, and --diff flags are not supported with the %s backend",
+				types.BackendDocker)
+		}
+		return r.composeStart()
+	}
+
+	if r.Flags.Start.Bundle != "" {
+		dir, err := r.useBundle()
+		defer os.RemoveAll(dir)
+		if err != nil {
+			return err
+		}
+	}
 
 	if err := utils.HelmChartVersionConstrains(
 		r.HelmClient.GetConfiguration().Version,
@@ -31,6 +56,23 @@ func (r *RasaCtl) Start() error {
 		return err
 	}
 
+	if r.Flags.Start.WithRasaServer && r.isRasaXDeployed {
+		return xerrors.Errorf(
+			"The --with-rasa-server flag is used only when a deployment is created, use the 'rasactl rasa enable %s' command instead",
+			r.Namespace,
+		)
+	}
+
+	if r.isDryRun() {
+		if r.isRasaXDeployed {
+			// Render a stopped deployment the same way as the start action does.
+			helmConfig := r.HelmClient.GetConfiguration()
+			helmConfig.StartProject = true
+			r.HelmClient.SetConfiguration(helmConfig)
+		}
+		return r.dryRun()
+	}
+
 	if err := r.KubernetesClient.CreateNamespace(); err != nil {
 		return err
 	}
@@ -17,6 +17,8 @@ package rasactl
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	"helm.sh/helm/v3/pkg/release"
 
@@ -69,101 +71,150 @@ func (r *RasaCtl) GetReleaseStatus(releaseName string) (string, *release.Release
 
 // Status prints status for a given deployment.
 func (r *RasaCtl) Status() error {
-	var d = [][]string{}
+	if r.Flags.Status.Watch {
+		return r.watchStatus()
+	}
+
+	return r.printStatus(os.Stdout)
+}
+
+// printStatus writes status for a given deployment to w.
+func (r *RasaCtl) printStatus(w io.Writer) error {
+	deployment, err := r.deploymentStatus()
+	if err != nil || deployment == nil {
+		return err
+	}
+
+	if !status.IsTableOutput(r.Flags.Status.Output) {
+		return status.FprintObject(w, deployment, r.Flags.Status.Output)
+	}
+
+	d := [][]string{
+		{"Name:", deployment.Name},
+		{"Status:", deployment.Status},
+		{"URL:", deployment.URL},
+		{"Version:", deployment.Version},
+		{"Enterprise:", deployment.Enterprise},
+	}
+
+	if deployment.RasaProductionVersion != "" {
+		d = append(d, []string{"Rasa production version:", deployment.RasaProductionVersion})
+		d = append(d, []string{"Rasa worker version:", deployment.RasaWorkerVersion})
+	}
+
+	if deployment.DatabaseMigration != "" {
+		d = append(d, []string{"Database migration:", deployment.DatabaseMigration})
+	}
+
+	d = append(d, []string{"Project path:", deployment.ProjectPath})
+
+	if r.Flags.Status.Details && deployment.HelmRelease != "" {
+		d = append(d, []string{"Helm chart:", deployment.HelmChart})
+		d = append(d, []string{"Helm release:", deployment.HelmRelease})
+		d = append(d, []string{"Helm release status:", deployment.HelmReleaseStatus})
+	}
+
+	status.FprintTableNoHeader(w, d)
+
+	if len(deployment.Pods) != 0 {
+		data := [][]string{}
+		for _, pod := range deployment.Pods {
+			data = append(data, []string{pod.Name, pod.Condition, pod.Status})
+		}
+
+		fmt.Fprintln(w)
+		status.FprintTable(w,
+			[]string{"Name", "Condition", "Status"},
+			data,
+		)
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// deploymentStatus returns status for a given deployment. It returns nil if the helm release status is not available.
+func (r *RasaCtl) deploymentStatus() (*types.DeploymentStatusOutput, error) {
+	if r.IsDockerBackend() {
+		return r.composeDeploymentStatus()
+	}
 
 	stateData, err := r.KubernetesClient.ReadSecretWithState()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	statusProject, release, err := r.GetReleaseStatus(string(stateData[types.StateHelmReleaseName]))
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 
-	d = append(d, []string{"Name:", r.Namespace})
-	d = append(d, []string{"Status:", statusProject})
-
 	url, err := r.GetRasaXURL()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	deployment := &types.DeploymentStatusOutput{
+		Name:        r.Namespace,
+		Status:      statusProject,
+		URL:         url,
+		ProjectPath: "not defined",
 	}
-	d = append(d, []string{"URL:", url})
 
 	r.initRasaXClient()
 	r.RasaXClient.URL = url
 
 	versionEndpoint, err := r.RasaXClient.GetVersionEndpoint()
 	if err != nil {
-		d = append(d, []string{"Version:", string(stateData[types.StateRasaXVersion])})
-		d = append(d, []string{"Enterprise:", string(stateData[types.StateEnterprise])})
+		deployment.Version = string(stateData[types.StateRasaXVersion])
+		deployment.Enterprise = string(stateData[types.StateEnterprise])
 	} else {
-		enterprise := "inactive"
+		deployment.Version = versionEndpoint.RasaX
+		deployment.Enterprise = "inactive"
 		if versionEndpoint.Enterprise {
-			enterprise = "active"
+			deployment.Enterprise = "active"
 		}
-		rasaProductionVersion := versionEndpoint.Rasa.Production
+
+		deployment.RasaProductionVersion = versionEndpoint.Rasa.Production
 		if versionEndpoint.Rasa.Production == "" {
-			rasaProductionVersion = "0.0.0"
+			deployment.RasaProductionVersion = "0.0.0"
 		}
 
-		rasaWorkerVersion := versionEndpoint.Rasa.Worker
+		deployment.RasaWorkerVersion = versionEndpoint.Rasa.Worker
 		if versionEndpoint.Rasa.Worker == "" {
-			rasaWorkerVersion = "0.0.0"
+			deployment.RasaWorkerVersion = "0.0.0"
 		}
+	}
 
-		d = append(d, []string{"Version:", versionEndpoint.RasaX})
-		d = append(d, []string{"Enterprise:", enterprise})
-		d = append(d, []string{"Rasa production version:", rasaProductionVersion})
-		d = append(d, []string{"Rasa worker version:", rasaWorkerVersion})
+	if r.Flags.Status.Watch {
+		if health, err := r.RasaXClient.GetHealthEndpoint(); err == nil {
+			deployment.DatabaseMigration = fmt.Sprintf("%s (%.0f%%)",
+				health.DatabaseMigration.Status, health.DatabaseMigration.ProgressInPercent)
+		}
 	}
 
-	projectPath := "not defined"
 	if string(stateData[types.StateProjectPath]) != "" {
-		projectPath = string(stateData[types.StateProjectPath])
+		deployment.ProjectPath = string(stateData[types.StateProjectPath])
 	}
-	d = append(d, []string{"Project path:", projectPath})
 
 	if r.Flags.Status.Details {
-		d = append(d, []string{"Helm chart:", fmt.Sprintf("%s-%s", release.Chart.Name(), release.Chart.Metadata.Version)})
-		d = append(d, []string{"Helm release:", release.Name})
-		d = append(d, []string{"Helm release status:", release.Info.Status.String()})
+		deployment.HelmChart = fmt.Sprintf("%s-%s", release.Chart.Name(), release.Chart.Metadata.Version)
+		deployment.HelmRelease = release.Name
+		deployment.HelmReleaseStatus = release.Info.Status.String()
 
 		pods, err := r.KubernetesClient.GetPods()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		data := [][]string{}
 		for _, pod := range pods.Items {
-			data = append(data,
-				[]string{
-					pod.Name,
-					r.KubernetesClient.PodStatus(pod.Status.Conditions),
-					string(pod.Status.Phase),
-				},
-			)
-		}
-
-		if len(pods.Items) != 0 {
-			status.PrintOutput(d, r.Flags.Status.Output)
-
-			if r.Flags.Status.Output == "table" {
-				fmt.Println()
-
-				status.PrintTable(
-					[]string{"Name", "Condition", "Status"},
-					data,
-				)
-				fmt.Println()
-			}
-		} else {
-			status.PrintOutput(d, r.Flags.Status.Output)
+			deployment.Pods = append(deployment.Pods, types.PodStatusOutput{
+				Name:      pod.Name,
+				Condition: r.KubernetesClient.PodStatus(pod.Status.Conditions),
+				Status:    string(pod.Status.Phase),
+			})
 		}
-		return nil
 	}
 
-	status.PrintOutput(d, r.Flags.Status.Output)
-
-	return nil
+	return deployment, nil
 }
@@ -0,0 +1,129 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// statusRefreshInterval defines how often the status is refreshed if there are no changes to pods.
+	// Rasa X endpoints can't be watched, they are checked with this interval.
+	statusRefreshInterval = 5 * time.Second
+
+	// statusRenderDelay groups pod changes that happen at the same time into one refresh.
+	statusRenderDelay = 500 * time.Millisecond
+)
+
+// watchStatus keeps refreshing status for a given deployment until the process is interrupted.
+//
+// The status is refreshed if pods change, and periodically for data read from Rasa X endpoints.
+// If stdout is a terminal the status is re-rendered in place, otherwise a new status is appended
+// each time it changes.
+func (r *RasaCtl) watchStatus() error {
+	ctx := context.Background()
+	isTerminal := term.IsTerminal(int(os.Stdout.Fd())) && r.Flags.Status.Output == "table"
+
+	// The pod table is a part of the status in the watch mode.
+	r.Flags.Status.Details = true
+
+	ticker := time.NewTicker(statusRefreshInterval)
+	defer ticker.Stop()
+
+	var (
+		podEvents <-chan watch.Event
+		watcher   watch.Interface
+		rendered  string
+		lines     int
+	)
+	defer func() {
+		if watcher != nil {
+			watcher.Stop()
+		}
+	}()
+
+	for {
+		// Containers of the docker backend are not watched, the status is refreshed periodically.
+		if podEvents == nil && !r.IsDockerBackend() {
+			w, err := r.KubernetesClient.WatchPods(ctx)
+			if err != nil {
+				r.Log.V(1).Info("Can't watch pods, the status is refreshed periodically", "error", err)
+			} else {
+				watcher = w
+				podEvents = watcher.ResultChan()
+			}
+		}
+
+		output := new(bytes.Buffer)
+		if err := r.printStatus(output); err != nil {
+			fmt.Fprintf(output, "Can't read the status: %s\n", err)
+		}
+
+		if current := output.String(); current != rendered {
+			rendered = current
+			switch {
+			case isTerminal:
+				if lines != 0 {
+					// Move the cursor to the beginning of the previous output and clear it.
+					fmt.Printf("\033[%dA\033[J", lines)
+				}
+				fmt.Print(rendered)
+				lines = strings.Count(rendered, "\n")
+			default:
+				fmt.Printf("--- %s\n", time.Now().Format(time.RFC3339))
+				fmt.Print(rendered)
+			}
+		}
+
+		select {
+		case _, ok := <-podEvents:
+			if !ok {
+				// The watch has been closed by the server, start a new one.
+				watcher.Stop()
+				podEvents = nil
+				watcher = nil
+				continue
+			}
+			drainEvents(podEvents, statusRenderDelay)
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainEvents reads events from a given channel for a given time, events are only used as a signal to refresh.
+func drainEvents(events <-chan watch.Event, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-timer.C:
+			return
+		}
+	}
+}
@@ -23,6 +23,10 @@ import (
 
 // Stop stops a deployment.
 func (r *RasaCtl) Stop() error {
+	if r.IsDockerBackend() {
+		return r.composeStop()
+	}
+
 	r.Spinner.Message("Stopping Rasa X")
 
 	r.initRasaXClient()
@@ -45,9 +49,8 @@ func (r *RasaCtl) Stop() error {
 		return err
 	}
 
-	if r.DockerClient.GetKind().ControlPlaneHost != "" && string(state[types.StateProjectPath]) != "" {
-		nodeName := fmt.Sprintf("kind-%s", r.Namespace)
-		if err := r.DockerClient.StopKindNode(nodeName); err != nil {
+	if r.isLocalCluster() && string(state[types.StateProjectPath]) != "" {
+		if err := r.LocalCluster.StopNode(); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,408 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// rasaServerMaxRestarts is the number of restarts after which a Rasa server is considered failed.
+	rasaServerMaxRestarts = 5
+
+	// rasaServerInitialBackoff is time to wait before the first restart of a Rasa server.
+	rasaServerInitialBackoff = time.Second
+
+	// rasaServerMaxBackoff is the maximum time to wait before restarting a Rasa server.
+	rasaServerMaxBackoff = time.Second * 30
+
+	// rasaServerStableTime is time after which a running Rasa server is considered stable,
+	// the restart counter and the backoff are reset if a stable server exits.
+	rasaServerStableTime = time.Minute
+
+	// rasaServerStopTimeout is time to wait for Rasa servers to exit before they are killed.
+	rasaServerStopTimeout = time.Second * 15
+
+	// rasaServerHealthInterval is an interval between health checks of a Rasa server.
+	rasaServerHealthInterval = time.Second * 5
+)
+
+// States of a supervised Rasa server.
+const (
+	rasaServerStarting   = "starting"
+	rasaServerRunning    = "running"
+	rasaServerRestarting = "restarting"
+	rasaServerFailed     = "failed"
+	rasaServerStopped    = "stopped"
+)
+
+// rasaServerHealth is a health status of a Rasa server, it's saved in a file for each environment.
+type rasaServerHealth struct {
+	Environment string    `json:"environment"`
+	State       string    `json:"state"`
+	Healthy     bool      `json:"healthy"`
+	PID         int       `json:"pid,omitempty"`
+	Port        int       `json:"port"`
+	Restarts    int       `json:"restarts"`
+	LastError   string    `json:"last_error,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// rasaServerPolicy defines how Rasa servers are restarted and stopped.
+type rasaServerPolicy struct {
+	maxRestarts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	stableTime     time.Duration
+	stopTimeout    time.Duration
+}
+
+// defaultRasaServerPolicy is the policy used for Rasa servers run by rasactl.
+var defaultRasaServerPolicy = rasaServerPolicy{
+	maxRestarts:    rasaServerMaxRestarts,
+	initialBackoff: rasaServerInitialBackoff,
+	maxBackoff:     rasaServerMaxBackoff,
+	stableTime:     rasaServerStableTime,
+	stopTimeout:    rasaServerStopTimeout,
+}
+
+// rasaServerProcess is a Rasa server process for a given environment.
+type rasaServerProcess struct {
+	environment string
+	command     string
+	args        []string
+	policy      rasaServerPolicy
+	port        int
+	healthPath  string
+	healthFile  string
+	log         logr.Logger
+
+	mu       sync.Mutex
+	cmd      *exec.Cmd
+	health   rasaServerHealth
+	stopping bool
+}
+
+// rasaServerSupervisor runs Rasa server processes, restarts them with backoff if they exit,
+// and forwards signals to them.
+type rasaServerSupervisor struct {
+	log       logr.Logger
+	healthDir string
+	processes []*rasaServerProcess
+
+	// command is a command run by processes, the rasa command is used by default.
+	command string
+	policy  rasaServerPolicy
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func newRasaServerSupervisor(log logr.Logger, healthDir string) *rasaServerSupervisor {
+	return &rasaServerSupervisor{
+		log:       log,
+		healthDir: healthDir,
+		command:   "rasa",
+		policy:    defaultRasaServerPolicy,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// add adds a process for a given environment, the command of the supervisor is run with given arguments.
+// The health of the process is checked by sending requests to healthPath on a given port.
+func (s *rasaServerSupervisor) add(environment string, args []string, port int, healthPath string) {
+	s.processes = append(s.processes, &rasaServerProcess{
+		environment: environment,
+		command:     s.command,
+		args:        args,
+		policy:      s.policy,
+		port:        port,
+		healthPath:  healthPath,
+		healthFile:  filepath.Join(s.healthDir, fmt.Sprintf("health-%s.json", environment)),
+		log:         s.log.WithValues("environment", environment),
+		health: rasaServerHealth{
+			Environment: environment,
+			Port:        port,
+		},
+	})
+}
+
+// run starts the Rasa servers and blocks until all of them are stopped.
+// It returns an error if one of the servers has failed permanently, the remaining servers are stopped in that case.
+func (s *rasaServerSupervisor) run() error {
+	if err := os.MkdirAll(s.healthDir, 0755); err != nil {
+		return err
+	}
+
+	errs := make(chan error, len(s.processes))
+	for _, p := range s.processes {
+		go p.checkHealth(s.stop)
+		go func(p *rasaServerProcess) {
+			errs <- p.run(s.stop)
+		}(p)
+	}
+
+	var failed error
+	for range s.processes {
+		if err := <-errs; err != nil && failed == nil {
+			failed = err
+			s.stopAll(syscall.SIGTERM)
+		}
+	}
+	close(s.done)
+
+	return failed
+}
+
+// stopAll forwards a given signal to the Rasa servers, the servers that don't exit
+// within the stop timeout of the policy are killed.
+func (s *rasaServerSupervisor) stopAll(sig os.Signal) {
+	s.stopOnce.Do(func() {
+		s.log.Info("Stopping Rasa servers", "signal", sig)
+		close(s.stop)
+		for _, p := range s.processes {
+			p.signal(sig)
+		}
+
+		time.AfterFunc(s.policy.stopTimeout, func() {
+			for _, p := range s.processes {
+				p.signal(syscall.SIGKILL)
+			}
+		})
+	})
+}
+
+// shutdown stops the Rasa servers and waits until they exit.
+func (s *rasaServerSupervisor) shutdown(sig os.Signal) {
+	if sig == nil {
+		sig = syscall.SIGTERM
+	}
+	s.stopAll(sig)
+	<-s.done
+}
+
+// run runs the Rasa server until it's stopped, the server is restarted with backoff if it exits.
+func (p *rasaServerProcess) run(stop <-chan struct{}) error {
+	backoff := p.policy.initialBackoff
+	restarts := 0
+
+	for {
+		started := time.Now()
+		exited, err := p.start()
+		if err == nil && exited != nil {
+			err = <-exited
+		}
+
+		if p.isStopping() {
+			p.setState(rasaServerStopped, restarts, nil)
+			return nil
+		}
+
+		if err == nil {
+			err = xerrors.Errorf("the process exited")
+		}
+
+		if time.Since(started) > p.policy.stableTime {
+			restarts = 0
+			backoff = p.policy.initialBackoff
+		}
+
+		if restarts >= p.policy.maxRestarts {
+			p.setState(rasaServerFailed, restarts, err)
+			return xerrors.Errorf("The Rasa server for the %s environment has failed after %d restarts: %s",
+				p.environment, restarts, err)
+		}
+
+		restarts++
+		p.setState(rasaServerRestarting, restarts, err)
+		p.log.Info("Rasa server has exited, restarting", "error", err.Error(), "backoff", backoff.String(), "restarts", restarts)
+
+		select {
+		case <-stop:
+			p.setState(rasaServerStopped, restarts, nil)
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > p.policy.maxBackoff {
+			backoff = p.policy.maxBackoff
+		}
+	}
+}
+
+// start starts the command of the process, its output is printed line by line with the environment prefix.
+// It returns a channel that receives the result of the process, the channel is nil if the process is stopping.
+func (p *rasaServerProcess) start() (<-chan error, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopping {
+		return nil, nil
+	}
+
+	p.log.V(1).Info("Starting Rasa server", "args", p.args)
+
+	cmd := exec.Command(p.command, p.args...)
+	// Run the server in a separate process group, signals are forwarded by the supervisor.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	if err := cmd.Start(); err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	output := make(chan struct{})
+	go func() {
+		printPrefixed(p.environment, reader)
+		close(output)
+	}()
+
+	exited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		writer.Close()
+		<-output
+
+		p.mu.Lock()
+		p.cmd = nil
+		p.mu.Unlock()
+
+		exited <- err
+	}()
+
+	p.cmd = cmd
+	p.health.PID = cmd.Process.Pid
+	p.health.State = rasaServerRunning
+	p.health.Healthy = false
+	p.health.LastError = ""
+	p.saveHealth()
+
+	return exited, nil
+}
+
+// printPrefixed prints lines read from a given reader, each line is prefixed with a given name.
+func printPrefixed(name string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fmt.Printf("(%s) %s\n", name, scanner.Text())
+	}
+}
+
+// signal marks the process as stopping and sends a given signal to its process group.
+func (p *rasaServerProcess) signal(sig os.Signal) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.stopping = true
+	if p.cmd == nil || p.cmd.Process == nil {
+		return
+	}
+
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		s = syscall.SIGTERM
+	}
+	if err := syscall.Kill(-p.cmd.Process.Pid, s); err != nil {
+		p.log.V(1).Info("Can't send a signal to Rasa server", "signal", s, "error", err.Error())
+	}
+}
+
+func (p *rasaServerProcess) isStopping() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.stopping
+}
+
+func (p *rasaServerProcess) setState(state string, restarts int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.health.State = state
+	p.health.Restarts = restarts
+	p.health.Healthy = false
+	p.health.PID = 0
+	if err != nil {
+		p.health.LastError = err.Error()
+	}
+	p.saveHealth()
+}
+
+// checkHealth periodically checks if the Rasa server responds to requests and saves the result.
+func (p *rasaServerProcess) checkHealth(stop <-chan struct{}) {
+	client := &http.Client{Timeout: time.Second * 2}
+	url := fmt.Sprintf("http://127.0.0.1:%d%s", p.port, p.healthPath)
+	ticker := time.NewTicker(rasaServerHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		healthy := false
+		if resp, err := client.Get(url); err == nil {
+			healthy = resp.StatusCode == http.StatusOK
+			resp.Body.Close()
+		}
+
+		p.mu.Lock()
+		if p.health.State == rasaServerRunning {
+			if healthy != p.health.Healthy {
+				p.log.Info("Rasa server health has changed", "healthy", healthy)
+			}
+			p.health.Healthy = healthy
+			p.saveHealth()
+		}
+		p.mu.Unlock()
+	}
+}
+
+// saveHealth writes the health status to a file, it has to be called with the lock held.
+func (p *rasaServerProcess) saveHealth() {
+	p.health.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(p.health, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := ioutil.WriteFile(p.healthFile, data, 0644); err != nil {
+		p.log.V(1).Info("Can't save the health status", "file", p.healthFile, "error", err.Error())
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSupervisor returns a supervisor that runs a given shell script for the test environment.
+func newTestSupervisor(t *testing.T, policy rasaServerPolicy, script string) *rasaServerSupervisor {
+	s := newRasaServerSupervisor(logr.Discard(), t.TempDir())
+	s.command = "sh"
+	s.policy = policy
+	s.add("test", []string{"-c", script}, 0, "/")
+	return s
+}
+
+// readTestHealth reads the health file of the test environment, the file could be partially written
+// if the supervisor is running.
+func readTestHealth(s *rasaServerSupervisor) (rasaServerHealth, error) {
+	health := rasaServerHealth{}
+	data, err := ioutil.ReadFile(filepath.Join(s.healthDir, "health-test.json"))
+	if err != nil {
+		return health, err
+	}
+
+	err = json.Unmarshal(data, &health)
+	return health, err
+}
+
+// waitForTestHealth waits until the health of the test environment matches a given condition.
+func waitForTestHealth(t *testing.T, s *rasaServerSupervisor, condition func(health rasaServerHealth) bool) {
+	require.Eventually(t, func() bool {
+		health, err := readTestHealth(s)
+		return err == nil && condition(health)
+	}, time.Second*5, time.Millisecond*20)
+}
+
+// runTestSupervisor runs a given supervisor in the background and returns a channel with its result.
+func runTestSupervisor(s *rasaServerSupervisor) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- s.run()
+	}()
+	return result
+}
+
+func TestSupervisorFailsAfterRestartLimit(t *testing.T) {
+	s := newTestSupervisor(t, rasaServerPolicy{
+		maxRestarts:    2,
+		initialBackoff: time.Millisecond * 10,
+		maxBackoff:     time.Millisecond * 20,
+		stableTime:     time.Minute,
+		stopTimeout:    time.Second,
+	}, "exit 1")
+
+	select {
+	case err := <-runTestSupervisor(s):
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has failed after 2 restarts")
+	case <-time.After(time.Second * 10):
+		t.Fatal("the supervisor hasn't failed")
+	}
+
+	health, err := readTestHealth(s)
+	require.NoError(t, err)
+	require.Equal(t, rasaServerFailed, health.State)
+	require.Equal(t, 2, health.Restarts)
+	require.NotEmpty(t, health.LastError)
+}
+
+func TestSupervisorRestartsAndStops(t *testing.T) {
+	s := newTestSupervisor(t, rasaServerPolicy{
+		maxRestarts:    5,
+		initialBackoff: time.Minute,
+		maxBackoff:     time.Minute,
+		stableTime:     time.Minute,
+		stopTimeout:    time.Second,
+	}, "exit 1")
+	result := runTestSupervisor(s)
+
+	// The process is waiting for a restart.
+	waitForTestHealth(t, s, func(health rasaServerHealth) bool {
+		return health.State == rasaServerRestarting && health.Restarts == 1
+	})
+
+	s.shutdown(syscall.SIGTERM)
+	require.NoError(t, <-result)
+	waitForTestHealth(t, s, func(health rasaServerHealth) bool {
+		return health.State == rasaServerStopped
+	})
+}
+
+func TestSupervisorResetsRestartsOfStableProcess(t *testing.T) {
+	s := newTestSupervisor(t, rasaServerPolicy{
+		maxRestarts:    1,
+		initialBackoff: time.Millisecond * 10,
+		maxBackoff:     time.Millisecond * 10,
+		stableTime:     time.Millisecond * 50,
+		stopTimeout:    time.Second,
+	}, "sleep 0.1; exit 1")
+	result := runTestSupervisor(s)
+
+	// The process exits several times, it isn't considered failed because it has been running longer than the stable time.
+	select {
+	case err := <-result:
+		t.Fatalf("the supervisor has exited: %v", err)
+	case <-time.After(time.Millisecond * 600):
+	}
+	waitForTestHealth(t, s, func(health rasaServerHealth) bool {
+		return health.Restarts <= 1
+	})
+
+	s.shutdown(syscall.SIGTERM)
+	require.NoError(t, <-result)
+}
+
+func TestSupervisorKillsProcessThatIgnoresSignal(t *testing.T) {
+	s := newTestSupervisor(t, rasaServerPolicy{
+		maxRestarts:    5,
+		initialBackoff: time.Minute,
+		maxBackoff:     time.Minute,
+		stableTime:     time.Minute,
+		stopTimeout:    time.Millisecond * 200,
+	}, "trap '' TERM; echo ready; sleep 30")
+	result := runTestSupervisor(s)
+
+	waitForTestHealth(t, s, func(health rasaServerHealth) bool {
+		return health.State == rasaServerRunning
+	})
+	// Wait until the trap is set.
+	time.Sleep(time.Millisecond * 200)
+
+	start := time.Now()
+	s.shutdown(syscall.SIGTERM)
+	require.NoError(t, <-result)
+	require.Less(t, int64(time.Since(start)), int64(time.Second*10))
+	waitForTestHealth(t, s, func(health rasaServerHealth) bool {
+		return health.State == rasaServerStopped
+	})
+}
@@ -0,0 +1,349 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/RasaHQ/rasactl/pkg/helm"
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+	"github.com/RasaHQ/rasactl/pkg/version"
+)
+
+const (
+	supportBundleMetadataFile string = "rasactl-support-bundle.yaml"
+	supportBundleErrorsFile   string = "errors.txt"
+	supportBundlePodsDir      string = "pods"
+	supportBundleLogsDir      string = "logs"
+	supportBundleHelmDir      string = "helm"
+	supportBundleRasaXDir     string = "rasa-x"
+)
+
+// supportBundleMetadata stores information about a support bundle.
+type supportBundleMetadata struct {
+	Deployment     string    `json:"deployment"`
+	CreatedAt      time.Time `json:"createdAt"`
+	RasaCtlVersion string    `json:"rasactlVersion"`
+}
+
+// SupportBundle collects diagnostic information about a given deployment into a gzip-compressed tar archive.
+//
+// The bundle includes pod descriptions, Kubernetes events, container logs, the helm release manifest
+// and values, the rasactl state, and responses from the Rasa X health and version endpoints.
+// Sensitive values are redacted. Information that can't be collected is recorded in the errors.txt file
+// instead of stopping the command, the bundle is mostly used for deployments that failed.
+func (r *RasaCtl) SupportBundle() error {
+	msg := "Collecting a support bundle"
+	r.Spinner.Message(msg)
+	r.Log.Info(msg, "namespace", r.Namespace, "file", r.Flags.SupportBundle.File)
+
+	dir, err := ioutil.TempDir("", fmt.Sprintf("rasactl-support-bundle-%s-", r.Namespace))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	collectErrors := []string{}
+	collect := func(description string, collector func(dir string) error) {
+		r.Spinner.Message(fmt.Sprintf("Collecting %s", description))
+		if err := collector(dir); err != nil {
+			r.Log.Info("Can't collect data for the support bundle", "data", description, "error", err)
+			collectErrors = append(collectErrors, fmt.Sprintf("%s: %s", description, err))
+		}
+	}
+
+	collect("the rasactl state", r.collectSupportBundleState)
+	collect("pods", r.collectSupportBundlePods)
+	collect("events", r.collectSupportBundleEvents)
+	collect("the helm release", r.collectSupportBundleHelmRelease)
+	collect("the Rasa X status", r.collectSupportBundleRasaX)
+
+	if len(collectErrors) != 0 {
+		content := strings.Join(collectErrors, "\n") + "\n"
+		if err := ioutil.WriteFile(filepath.Join(dir, supportBundleErrorsFile), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+
+	metadata := supportBundleMetadata{
+		Deployment:     r.Namespace,
+		CreatedAt:      time.Now().UTC(),
+		RasaCtlVersion: version.VERSION,
+	}
+	if err := writeYAMLFile(filepath.Join(dir, supportBundleMetadataFile), metadata); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Writing the support bundle archive")
+	if err := utils.CreateArchive(dir, r.Flags.SupportBundle.File); err != nil {
+		return err
+	}
+
+	r.Spinner.Stop()
+	fmt.Printf("The support bundle for the %s deployment has been saved to %s\n", r.Namespace, r.Flags.SupportBundle.File)
+	if len(collectErrors) != 0 {
+		fmt.Printf("Some data couldn't be collected, see the %s file in the bundle for details.\n", supportBundleErrorsFile)
+	}
+
+	return nil
+}
+
+func (r *RasaCtl) collectSupportBundleState(dir string) error {
+	state, err := r.KubernetesClient.ReadSecretWithState()
+	if err != nil {
+		return err
+	}
+
+	stateData := map[string]interface{}{}
+	for key, value := range state {
+		stateData[key] = string(value)
+	}
+
+	if environments, ok := state[types.StateEnvironments]; ok {
+		environmentsData := []interface{}{}
+		if err := json.Unmarshal(environments, &environmentsData); err == nil {
+			stateData[types.StateEnvironments] = environmentsData
+		}
+	}
+
+	return writeYAMLFile(filepath.Join(dir, "state.yaml"), utils.RedactValues(stateData))
+}
+
+func (r *RasaCtl) collectSupportBundlePods(dir string) error {
+	pods, err := r.KubernetesClient.GetPods()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, supportBundlePodsDir), 0755); err != nil {
+		return err
+	}
+
+	collectErrors := []string{}
+	for _, pod := range pods.Items {
+		description, err := r.KubernetesClient.DescribePod(pod.Name)
+		if err != nil {
+			collectErrors = append(collectErrors, fmt.Sprintf("can't describe the %s pod: %s", pod.Name, err))
+		} else {
+			file := filepath.Join(dir, supportBundlePodsDir, fmt.Sprintf("%s.txt", pod.Name))
+			if err := ioutil.WriteFile(file, []byte(description), 0644); err != nil {
+				return err
+			}
+		}
+
+		if err := r.collectSupportBundlePodLogs(filepath.Join(dir, supportBundleLogsDir, pod.Name), pod); err != nil {
+			collectErrors = append(collectErrors, err.Error())
+		}
+	}
+
+	if len(collectErrors) != 0 {
+		return xerrors.New(strings.Join(collectErrors, ", "))
+	}
+
+	return nil
+}
+
+// collectSupportBundlePodLogs saves logs of all containers of a given pod.
+// Logs of the previous instance of a container are saved if the container has been restarted.
+func (r *RasaCtl) collectSupportBundlePodLogs(dir string, pod v1.Pod) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	restarts := map[string]int32{}
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		restarts[status.Name] = status.RestartCount
+	}
+
+	containers := []string{}
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		containers = append(containers, container.Name)
+	}
+
+	// The logs request is configured by the logs flags.
+	logsFlags := r.Flags.Logs
+	defer func() {
+		r.Flags.Logs = logsFlags
+	}()
+	r.Flags.Logs = types.RasaCtlLogsFlags{}
+
+	collectErrors := []string{}
+	for _, container := range containers {
+		r.Flags.Logs.Container = container
+		r.Flags.Logs.Previous = false
+		if err := r.saveContainerLogs(pod.Name, filepath.Join(dir, fmt.Sprintf("%s.log", container))); err != nil {
+			collectErrors = append(collectErrors, fmt.Sprintf("can't read logs for the %s container in the %s pod: %s", container, pod.Name, err))
+		}
+
+		if restarts[container] == 0 {
+			continue
+		}
+
+		r.Flags.Logs.Previous = true
+		if err := r.saveContainerLogs(pod.Name, filepath.Join(dir, fmt.Sprintf("%s.previous.log", container))); err != nil {
+			collectErrors = append(collectErrors,
+				fmt.Sprintf("can't read previous logs for the %s container in the %s pod: %s", container, pod.Name, err))
+		}
+	}
+
+	if len(collectErrors) != 0 {
+		return xerrors.New(strings.Join(collectErrors, ", "))
+	}
+
+	return nil
+}
+
+func (r *RasaCtl) saveContainerLogs(pod, file string) error {
+	stream, err := r.KubernetesClient.GetLogs(pod).Stream(context.TODO())
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, stream)
+	return err
+}
+
+func (r *RasaCtl) collectSupportBundleEvents(dir string) error {
+	events, err := r.KubernetesClient.GetEvents()
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(events.Items, func(i, j int) bool {
+		return eventTime(events.Items[i]).Before(eventTime(events.Items[j]))
+	})
+
+	data := [][]string{}
+	for _, event := range events.Items {
+		timestamp := eventTime(event)
+
+		data = append(data, []string{
+			timestamp.UTC().Format(time.RFC3339),
+			event.Type,
+			event.Reason,
+			fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+			strings.TrimSpace(event.Message),
+		})
+	}
+
+	var content strings.Builder
+	for _, row := range data {
+		content.WriteString(strings.Join(row, "\t"))
+		content.WriteString("\n")
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, "events.txt"), []byte(content.String()), 0644)
+}
+
+func (r *RasaCtl) collectSupportBundleHelmRelease(dir string) error {
+	release, err := r.HelmClient.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, supportBundleHelmDir), 0755); err != nil {
+		return err
+	}
+
+	manifest, err := helm.RedactManifest(release.Manifest)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, supportBundleHelmDir, "manifest.yaml"), []byte(manifest), 0644); err != nil {
+		return err
+	}
+
+	releaseInfo := map[string]interface{}{
+		"name":         release.Name,
+		"revision":     release.Version,
+		"status":       release.Info.Status.String(),
+		"description":  release.Info.Description,
+		"lastDeployed": release.Info.LastDeployed.UTC().Format(time.RFC3339),
+	}
+	if release.Chart != nil && release.Chart.Metadata != nil {
+		releaseInfo["chart"] = fmt.Sprintf("%s-%s", release.Chart.Metadata.Name, release.Chart.Metadata.Version)
+	}
+	if err := writeYAMLFile(filepath.Join(dir, supportBundleHelmDir, "release.yaml"), releaseInfo); err != nil {
+		return err
+	}
+
+	if err := r.GetAllHelmValues(); err != nil {
+		return err
+	}
+
+	return writeYAMLFile(filepath.Join(dir, supportBundleHelmDir, "values.yaml"), utils.RedactValues(r.HelmClient.GetValues()))
+}
+
+func (r *RasaCtl) collectSupportBundleRasaX(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, supportBundleRasaXDir), 0755); err != nil {
+		return err
+	}
+
+	r.initRasaXClient()
+	if r.RasaXClient.URL == "" {
+		return xerrors.New("can't determine the Rasa X URL")
+	}
+
+	collectErrors := []string{}
+
+	health, err := r.RasaXClient.GetHealthEndpoint()
+	if err != nil {
+		collectErrors = append(collectErrors, fmt.Sprintf("/api/health: %s", err))
+	} else if err := writeJSONFile(filepath.Join(dir, supportBundleRasaXDir, "health.json"), health); err != nil {
+		return err
+	}
+
+	versionEndpoint, err := r.RasaXClient.GetVersionEndpoint()
+	if err != nil {
+		collectErrors = append(collectErrors, fmt.Sprintf("/api/version: %s", err))
+	} else if err := writeJSONFile(filepath.Join(dir, supportBundleRasaXDir, "version.json"), versionEndpoint); err != nil {
+		return err
+	}
+
+	if len(collectErrors) != 0 {
+		return xerrors.New(strings.Join(collectErrors, ", "))
+	}
+
+	return nil
+}
+
+func writeJSONFile(file string, data interface{}) error {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(content, '\n'), 0644)
+}
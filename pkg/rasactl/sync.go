@@ -0,0 +1,387 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/k8s"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	// syncDelay groups file changes that happen at the same time into one update.
+	syncDelay = 500 * time.Millisecond
+
+	// syncPodTimeout defines time to wait for the sync pod to be running.
+	syncPodTimeout = 5 * time.Minute
+)
+
+// syncExcludes defines files and directories of a local project that are not synced.
+var syncExcludes = map[string]bool{
+	".git":        true,
+	".rasa":       true,
+	".rasactl":    true,
+	"__pycache__": true,
+}
+
+// syncRootExcludes defines directories in the root of a local project that are not synced.
+// Trained models are stored in Rasa X, they're not read from the project directory.
+var syncRootExcludes = map[string]bool{
+	"models": true,
+}
+
+// useProjectSync creates a volume for a local project and syncs the project into it.
+// It's used if the current Kubernetes cluster is not a local cluster, so that a host path can't be mounted.
+func (r *RasaCtl) useProjectSync(projectPath string) error {
+	r.Spinner.Message("Creating a volume for the project")
+	volume, err := r.KubernetesClient.CreateSyncVolume()
+	if err != nil {
+		return err
+	}
+
+	if err := r.startSyncPod(); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Syncing the project")
+	if err := r.uploadProjectFiles(projectPath, []string{"."}); err != nil {
+		return err
+	}
+
+	r.HelmClient.SetPersistanceVolumeClaimName(volume)
+	r.HelmClient.SetUseSyncPod(true)
+	r.syncProjectPath = projectPath
+
+	return nil
+}
+
+// isProjectSynced returns true if a given project path is synced by the helper pod.
+func (r *RasaCtl) isProjectSynced(projectPath string) bool {
+	return projectPath != "" && !r.isLocalCluster()
+}
+
+// startSyncPod creates the helper pod if it doesn't exist and waits until it's running.
+func (r *RasaCtl) startSyncPod() error {
+	r.Spinner.Message("Starting the sync pod")
+	if err := r.KubernetesClient.CreateSyncPod(); err != nil {
+		return err
+	}
+
+	return r.KubernetesClient.WaitForSyncPod(syncPodTimeout)
+}
+
+// SyncProject syncs a local project with a deployment and keeps syncing changes until the process is interrupted.
+func (r *RasaCtl) SyncProject() error {
+	state, err := r.KubernetesClient.ReadSecretWithState()
+	if err != nil {
+		return err
+	}
+
+	projectPath := string(state[types.StateProjectPath])
+	if projectPath == "" {
+		return xerrors.Errorf("The %s deployment doesn't use a local project", r.Namespace)
+	}
+
+	if !r.isProjectSynced(projectPath) {
+		return xerrors.Errorf("The %s deployment uses a local cluster, the project directory is mounted directly and doesn't have to be synced",
+			r.Namespace)
+	}
+
+	if err := r.startSyncPod(); err != nil {
+		return err
+	}
+
+	r.Spinner.Message("Syncing the project")
+	if err := r.uploadProjectFiles(projectPath, []string{"."}); err != nil {
+		return err
+	}
+	r.Spinner.Stop()
+
+	return r.watchProject(context.Background(), projectPath)
+}
+
+// IsProjectSynced returns true if the project used by the deployment started by the Start method
+// is synced by the helper pod, so that changes are synced only by the WatchSyncedProject method or the 'rasactl sync' command.
+func (r *RasaCtl) IsProjectSynced() bool {
+	return r.syncProjectPath != ""
+}
+
+// WatchSyncedProject syncs changes in the project synced by the Start method until the context is canceled.
+// It does nothing if the project is not synced by the helper pod.
+func (r *RasaCtl) WatchSyncedProject(ctx context.Context) error {
+	if !r.IsProjectSynced() {
+		return nil
+	}
+	return r.watchProject(ctx, r.syncProjectPath)
+}
+
+// watchProject watches a local project for changes and syncs changed files with the sync pod.
+func (r *RasaCtl) watchProject(ctx context.Context, projectPath string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := addProjectWatches(watcher, projectPath, projectPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Syncing changes in %s with the %s deployment, press Ctrl+C to stop\n", projectPath, r.Namespace)
+
+	timer := time.NewTimer(syncDelay)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	changed := map[string]bool{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			rel, err := filepath.Rel(projectPath, event.Name)
+			if err != nil || isSyncExcluded(rel) {
+				continue
+			}
+			changed[rel] = true
+			timer.Reset(syncDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.Log.Info("Error while watching the project", "error", err)
+		case <-timer.C:
+			if err := r.syncChanges(watcher, projectPath, changed); err != nil {
+				fmt.Printf("Can't sync changes: %s\n", err)
+			}
+			changed = map[string]bool{}
+		}
+	}
+}
+
+// syncChanges uploads changed files and removes deleted files in the sync pod.
+func (r *RasaCtl) syncChanges(watcher *fsnotify.Watcher, projectPath string, changed map[string]bool) error {
+	updated := []string{}
+	removed := []string{}
+
+	for rel := range changed {
+		file := filepath.Join(projectPath, rel)
+		info, err := os.Stat(file)
+		switch {
+		case os.IsNotExist(err):
+			removed = append(removed, path.Join(k8s.SyncPodMountPath, filepath.ToSlash(rel)))
+		case err != nil:
+			return err
+		default:
+			if info.IsDir() {
+				// Directories are not watched recursively, watch a new directory and its subdirectories.
+				if err := addProjectWatches(watcher, projectPath, file); err != nil {
+					return err
+				}
+			}
+			updated = append(updated, rel)
+		}
+	}
+	sort.Strings(updated)
+	sort.Strings(removed)
+
+	if len(removed) != 0 {
+		stderr := new(bytes.Buffer)
+		command := append([]string{"rm", "-rf"}, removed...)
+		if err := r.KubernetesClient.Exec(k8s.SyncPodName, "", command, nil, nil, stderr); err != nil {
+			return xerrors.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	if len(updated) != 0 {
+		if err := r.uploadProjectFiles(projectPath, updated); err != nil {
+			return err
+		}
+	}
+
+	r.Log.Info("Synced changes", "updated", updated, "removed", removed)
+	return nil
+}
+
+// uploadProjectFiles uploads given paths of a local project to the sync pod.
+// The files are sent as a tar archive over exec.
+func (r *RasaCtl) uploadProjectFiles(projectPath string, paths []string) error {
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		writer.CloseWithError(writeProjectArchive(writer, projectPath, paths))
+	}()
+
+	stderr := new(bytes.Buffer)
+	command := []string{"tar", "-xf", "-", "-C", k8s.SyncPodMountPath}
+	if err := r.KubernetesClient.Exec(k8s.SyncPodName, "", command, reader, nil, stderr); err != nil {
+		return xerrors.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// writeProjectArchive writes given paths of a local project to a tar archive, directories are added recursively.
+func writeProjectArchive(w io.Writer, projectPath string, paths []string) error {
+	tw := tar.NewWriter(w)
+
+	for _, p := range paths {
+		err := filepath.Walk(filepath.Join(projectPath, p), func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				// The file could be removed in the meantime.
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			rel, err := filepath.Rel(projectPath, file)
+			if err != nil {
+				return err
+			}
+
+			if isSyncExcluded(rel) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			// Skip the project root, symlinks, and special files.
+			if rel == "." || (!info.IsDir() && !info.Mode().IsRegular()) {
+				return nil
+			}
+
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(rel)
+
+			if info.IsDir() {
+				return tw.WriteHeader(header)
+			}
+
+			return writeProjectArchiveFile(tw, header, file)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// addProjectWatches adds a given directory and its subdirectories to the watcher.
+func addProjectWatches(watcher *fsnotify.Watcher, projectPath, dir string) error {
+	return filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(projectPath, file)
+		if err != nil {
+			return err
+		}
+
+		if isSyncExcluded(rel) {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(file)
+	})
+}
+
+// writeProjectArchiveFile streams a given file into the archive.
+// The file could be modified in the meantime, only the size of the opened file is copied,
+// and a file that shrinks is padded with zeros, it's synced again with the next change.
+func writeProjectArchiveFile(tw *tar.Writer, header *tar.Header, file string) error {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header.Size = info.Size()
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	n, err := io.CopyN(tw, f, header.Size)
+	if err == io.EOF {
+		_, err = io.CopyN(tw, zeroReader{}, header.Size-n)
+	}
+	return err
+}
+
+// zeroReader is a reader that returns an infinite stream of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// isSyncExcluded returns true if a given path relative to the project directory is excluded from syncing.
+func isSyncExcluded(rel string) bool {
+	elements := strings.Split(filepath.ToSlash(rel), "/")
+	if syncRootExcludes[elements[0]] {
+		return true
+	}
+
+	for _, element := range elements {
+		if syncExcludes[element] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSyncExcluded(t *testing.T) {
+	tests := []struct {
+		path     string
+		excluded bool
+	}{
+		{path: "domain.yml", excluded: false},
+		{path: "data/nlu.yml", excluded: false},
+		{path: ".git", excluded: true},
+		{path: ".git/config", excluded: true},
+		{path: "actions/__pycache__/actions.pyc", excluded: true},
+		{path: ".rasa/cache", excluded: true},
+		{path: ".rasactl/health-production.json", excluded: true},
+		{path: "models", excluded: true},
+		{path: "models/20211104-113220.tar.gz", excluded: true},
+		{path: "actions/models/user.py", excluded: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			require.Equal(t, test.excluded, isSyncExcluded(test.path))
+		})
+	}
+}
+
+func TestWriteProjectArchive(t *testing.T) {
+	projectPath := t.TempDir()
+	files := map[string]string{
+		"domain.yml":                    "version: '2.0'\n",
+		"data/nlu.yml":                  "nlu: []\n",
+		"actions/models/user.py":        "class User: pass\n",
+		".git/config":                   "[core]\n",
+		"actions/__pycache__/a.pyc":     "pyc",
+		"models/20211104-113220.tar.gz": "model",
+	}
+	for name, content := range files {
+		file := filepath.Join(projectPath, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	}
+
+	archive := new(bytes.Buffer)
+	require.NoError(t, writeProjectArchive(archive, projectPath, []string{"."}))
+
+	entries := map[string]string{}
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		require.Equal(t, header.Size, int64(len(content)))
+		entries[header.Name] = string(content)
+	}
+
+	require.Equal(t, map[string]string{
+		"actions":                "",
+		"actions/models":         "",
+		"actions/models/user.py": files["actions/models/user.py"],
+		"data":                   "",
+		"data/nlu.yml":           files["data/nlu.yml"],
+		"domain.yml":             files["domain.yml"],
+	}, entries)
+}
+
+func TestWriteProjectArchiveChangedFiles(t *testing.T) {
+	projectPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "domain.yml"), []byte("version: '2.0'\n"), 0644))
+
+	// Only changed files are written, a removed file is skipped.
+	archive := new(bytes.Buffer)
+	require.NoError(t, writeProjectArchive(archive, projectPath, []string{"domain.yml", "removed.yml"}))
+
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "domain.yml", header.Name)
+
+	_, err = tr.Next()
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,254 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/xerrors"
+
+	"github.com/RasaHQ/rasactl/pkg/docker"
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasa"
+)
+
+const (
+	// trainDelay groups project changes that happen within a short time into one training.
+	trainDelay = 2 * time.Second
+
+	// modelLoadTimeout defines time to wait for the Rasa server to load a new model.
+	modelLoadTimeout = 5 * time.Minute
+
+	// trainModelsDir is a directory in the project where models trained by the watch mode are stored.
+	trainModelsDir = ".rasactl/models"
+)
+
+// trainingFiles lists files and directories of a project that are used to train a model.
+var trainingFiles = []string{"data", "domain.yml", "domain.yaml", "domain", "config.yml", "config.yaml"}
+
+// isTrainingFile checks if a given path relative to the project directory is used to train a model.
+func isTrainingFile(rel string) bool {
+	first := strings.Split(filepath.ToSlash(rel), "/")[0]
+	for _, f := range trainingFiles {
+		if first == f {
+			return true
+		}
+	}
+	return false
+}
+
+// watchAndTrain watches a project for changes in training data, domain and configuration,
+// and trains a new model if the project changes. The model is uploaded to Rasa X, tagged as production,
+// and the function waits until the Rasa server that runs on a given port loads the model.
+func (r *RasaCtl) watchAndTrain(ctx context.Context, projectPath, image, rasaToken string, port int) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := addProjectWatches(watcher, projectPath, projectPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Watching %s for changes, a new model is trained and deployed if the project changes\n", projectPath)
+
+	timer := time.NewTimer(trainDelay)
+	if !timer.Stop() {
+		<-timer.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			rel, err := filepath.Rel(projectPath, event.Name)
+			if err != nil || isSyncExcluded(rel) {
+				continue
+			}
+
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Directories are not watched recursively, watch a new directory and its subdirectories.
+					if err := addProjectWatches(watcher, projectPath, event.Name); err != nil {
+						return err
+					}
+				}
+			}
+
+			if isTrainingFile(rel) {
+				r.Log.V(1).Info("Project has changed", "file", rel, "op", event.Op.String())
+				timer.Reset(trainDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.Log.Info("Error while watching the project", "error", err)
+		case <-timer.C:
+			if err := r.trainAndDeployModel(ctx, projectPath, image, rasaToken, port); err != nil {
+				fmt.Printf("Can't deploy a new model: %s\n", err)
+			}
+		}
+	}
+}
+
+// trainAndDeployModel trains a new model, uploads it to Rasa X and tags it as production.
+func (r *RasaCtl) trainAndDeployModel(ctx context.Context, projectPath, image, rasaToken string, port int) error {
+	name := fmt.Sprintf("rasactl-%s", time.Now().Format("20060102-150405"))
+
+	fmt.Printf("Project has changed, training the %s model\n", name)
+	file, err := r.trainModel(projectPath, image, name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file)
+
+	token, err := r.getAuthToken()
+	if err != nil {
+		return err
+	}
+	r.RasaXClient.BearerToken = token
+
+	if err := r.RasaXClient.ModelUpload(file, r.Flags.Model.Upload.Timeout); err != nil {
+		return err
+	}
+
+	if err := r.RasaXClient.ModelTag(name, "production"); err != nil {
+		return err
+	}
+
+	trainedAt, err := r.modelTrainedAt(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Waiting for the Rasa server to load the %s model\n", name)
+	if err := waitForModel(ctx, port, rasaToken, trainedAt); err != nil {
+		return err
+	}
+	fmt.Printf("The %s model has been loaded by the Rasa server\n", name)
+
+	return nil
+}
+
+// trainModel runs the rasa train command and returns a path to the trained model.
+// The command runs in a container if an image is given.
+func (r *RasaCtl) trainModel(projectPath, image, name string) (string, error) {
+	args := []string{"train", "--fixed-model-name", name}
+	output := filepath.Join(projectPath, filepath.FromSlash(trainModelsDir))
+
+	if image != "" {
+		reader, writer := io.Pipe()
+		go printPrefixed("train", reader)
+		defer writer.Close()
+
+		return filepath.Join(output, name+".tar.gz"), r.DockerClient.RunRasaCommand(docker.RasaServerSpec{
+			Name:        docker.RasaServerContainerName(r.Namespace, "train"),
+			Image:       image,
+			ProjectPath: projectPath,
+			Args:        append(args, "--out", path.Join(docker.RasaServerWorkDir, trainModelsDir)),
+		}, writer)
+	}
+
+	cmd := exec.Command("rasa", append(args, "--out", output)...)
+	cmd.Dir = projectPath
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	go printPrefixed("train", reader)
+	defer writer.Close()
+
+	r.Log.V(1).Info("Training a model", "args", cmd.Args)
+	if err := cmd.Run(); err != nil {
+		return "", xerrors.Errorf("rasa train has failed: %s", err)
+	}
+
+	return filepath.Join(output, name+".tar.gz"), nil
+}
+
+// modelTrainedAt returns the training time of a given model stored in Rasa X.
+func (r *RasaCtl) modelTrainedAt(name string) (float64, error) {
+	models, err := r.RasaXClient.ModelList()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, model := range models.Models {
+		if model.Model == name {
+			return model.TrainedAt, nil
+		}
+	}
+
+	return 0, xerrors.Errorf("model '%s' not found", name)
+}
+
+// waitForModel waits until the Rasa server that runs on a given port loads a model trained at a given time.
+func waitForModel(ctx context.Context, port int, rasaToken string, trainedAt float64) error {
+	ctx, cancel := context.WithTimeout(ctx, modelLoadTimeout)
+	defer cancel()
+
+	client := &http.Client{Timeout: time.Second * 5}
+	url := fmt.Sprintf("http://127.0.0.1:%d/status?token=%s", port, rasaToken)
+
+	for {
+		if status, err := getRasaServerStatus(client, url); err == nil && math.Abs(status.Fingerprint.TrainedAt-trainedAt) < 0.001 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return xerrors.Errorf("Error while waiting for the Rasa server to load the model, error: %s", ctx.Err())
+		case <-time.After(time.Second * 2):
+		}
+	}
+}
+
+func getRasaServerStatus(client *http.Client, url string) (*rtypes.StatusEndpointResponse, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	status := &rtypes.StatusEndpointResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
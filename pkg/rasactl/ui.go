@@ -0,0 +1,644 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasactl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pkg/browser"
+	"github.com/rivo/tview"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+const (
+	// uiRefreshInterval defines how often data shown in the dashboard is refreshed.
+	uiRefreshInterval = 5 * time.Second
+
+	// uiLogsTailLines is the number of recent log lines shown for a pod.
+	uiLogsTailLines int64 = 200
+
+	uiMainPage  = "main"
+	uiModalPage = "modal"
+
+	uiHelp = "[yellow]s[white] start  [yellow]x[white] stop  [yellow]o[white] open  [yellow]l[white] logs  " +
+		"[yellow]t[white] tag model  [yellow]d[white] delete model  [yellow]r[white] refresh  " +
+		"[yellow]tab[white] switch panel  [yellow]q[white] quit"
+)
+
+// dashboard is a full-screen terminal dashboard for rasactl deployments.
+type dashboard struct {
+	r   *RasaCtl
+	app *tview.Application
+
+	// mu guards the RasaCtl object, it's not safe for concurrent use,
+	// and clients are switched between deployments, use withDeployment to access it.
+	mu sync.Mutex
+
+	// selectedMu guards the selected field, it's written in the UI goroutine and read by background goroutines.
+	selectedMu sync.Mutex
+
+	pages       *tview.Pages
+	deployments *tview.Table
+	details     *tview.TextView
+	pods        *tview.Table
+	models      *tview.Table
+	logs        *tview.TextView
+	footer      *tview.TextView
+	panels      []tview.Primitive
+
+	// selected is the name of the selected deployment.
+	selected string
+
+	// cancelLogs stops streaming logs for the current pod.
+	cancelLogs context.CancelFunc
+}
+
+// UI runs an interactive terminal dashboard that shows deployments with their status, pods, logs and models.
+// Actions such as starting or stopping a deployment call the same methods as the rasactl commands,
+// they are executed with the dashboard suspended so that their output is visible.
+func (r *RasaCtl) UI() error {
+	d := &dashboard{
+		r:   r,
+		app: tview.NewApplication(),
+	}
+	d.layout()
+
+	refresh := make(chan struct{}, 1)
+	go d.refreshLoop(refresh)
+	d.requestRefresh(refresh)
+
+	d.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if name, _ := d.pages.GetFrontPage(); name != uiMainPage {
+			return event
+		}
+		return d.handleKey(event, refresh)
+	})
+
+	defer d.stopLogs()
+	return d.app.SetRoot(d.pages, true).SetFocus(d.deployments).Run()
+}
+
+func (d *dashboard) layout() {
+	newTable := func(title string) *tview.Table {
+		table := tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+		table.SetBorder(true).SetTitle(title)
+		return table
+	}
+
+	d.deployments = newTable(" Deployments ")
+	d.pods = newTable(" Pods ")
+	d.models = newTable(" Models ")
+
+	d.details = tview.NewTextView().SetDynamicColors(true)
+	d.details.SetBorder(true).SetTitle(" Status ")
+
+	d.logs = tview.NewTextView().SetScrollable(true).SetChangedFunc(func() { d.app.Draw() })
+	d.logs.SetBorder(true).SetTitle(" Logs ")
+
+	d.footer = tview.NewTextView().SetDynamicColors(true).SetText(uiHelp)
+
+	d.deployments.SetSelectionChangedFunc(func(row, column int) {
+		if row < 1 || row >= d.deployments.GetRowCount() {
+			return
+		}
+		name := d.deployments.GetCell(row, 0).Text
+		if name != d.getSelected() {
+			d.setSelected(name)
+			d.stopLogs()
+			d.logs.Clear()
+			go d.refreshSelected()
+		}
+	})
+
+	right := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(d.details, 12, 0, false).
+		AddItem(d.pods, 0, 1, false).
+		AddItem(d.models, 0, 1, false)
+
+	main := tview.NewFlex().
+		AddItem(d.deployments, 0, 1, true).
+		AddItem(right, 0, 2, false)
+
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(main, 0, 2, true).
+		AddItem(d.logs, 0, 1, false).
+		AddItem(d.footer, 1, 0, false)
+
+	d.panels = []tview.Primitive{d.deployments, d.pods, d.models, d.logs}
+	d.pages = tview.NewPages().AddPage(uiMainPage, root, true, true)
+}
+
+func (d *dashboard) handleKey(event *tcell.EventKey, refresh chan struct{}) *tcell.EventKey {
+	if event.Key() == tcell.KeyTab {
+		d.focusNext()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'q':
+		d.app.Stop()
+	case 'r':
+		d.requestRefresh(refresh)
+	case 's':
+		d.startDeployment(refresh)
+	case 'x':
+		d.stopDeployment(refresh)
+	case 'o':
+		d.openDeployment()
+	case 'l':
+		d.tailLogs()
+	case 't':
+		d.tagModel(refresh)
+	case 'd':
+		d.deleteModel(refresh)
+	default:
+		return event
+	}
+
+	return nil
+}
+
+func (d *dashboard) focusNext() {
+	current := d.app.GetFocus()
+	for i, panel := range d.panels {
+		if panel == current {
+			d.app.SetFocus(d.panels[(i+1)%len(d.panels)])
+			return
+		}
+	}
+	d.app.SetFocus(d.panels[0])
+}
+
+func (d *dashboard) message(format string, a ...interface{}) {
+	d.footer.SetText(fmt.Sprintf("[yellow]%s[white]  |  %s", tview.Escape(fmt.Sprintf(format, a...)), uiHelp))
+}
+
+func (d *dashboard) requestRefresh(refresh chan struct{}) {
+	select {
+	case refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (d *dashboard) refreshLoop(refresh chan struct{}) {
+	ticker := time.NewTicker(uiRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-refresh:
+		}
+		d.refreshDeployments()
+		d.refreshSelected()
+	}
+}
+
+func (d *dashboard) getSelected() string {
+	d.selectedMu.Lock()
+	defer d.selectedMu.Unlock()
+	return d.selected
+}
+
+func (d *dashboard) setSelected(name string) {
+	d.selectedMu.Lock()
+	defer d.selectedMu.Unlock()
+	d.selected = name
+}
+
+// withDeployment switches clients to a given deployment and runs a given function.
+// Clients are shared, so switching is serialized, and clients are switched back to the previous deployment
+// afterwards so that a background refresh doesn't change the deployment used by other actions.
+func (d *dashboard) withDeployment(namespace string, fn func() error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	previous := d.r.Namespace
+	if previous != "" && previous != namespace {
+		defer func() {
+			//nolint:errcheck
+			d.useDeployment(previous)
+		}()
+	}
+
+	if err := d.useDeployment(namespace); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// useDeployment switches clients to a given deployment, it has to be called with the mu lock held.
+func (d *dashboard) useDeployment(namespace string) error {
+	r := d.r
+	r.Namespace = namespace
+	if err := r.SetNamespaceClients(namespace); err != nil {
+		return err
+	}
+
+	state, err := r.KubernetesClient.ReadSecretWithState()
+	if err != nil {
+		return err
+	}
+
+	releaseName := string(state[types.StateHelmReleaseName])
+	r.HelmClient.SetConfiguration(&types.HelmConfigurationSpec{
+		ReleaseName: releaseName,
+		Timeout:     time.Minute * 15,
+	})
+	r.KubernetesClient.SetHelmReleaseName(releaseName)
+
+	return nil
+}
+
+func (d *dashboard) refreshDeployments() {
+	d.mu.Lock()
+	namespaces, err := d.r.KubernetesClient.GetNamespaces()
+	d.mu.Unlock()
+
+	rows := [][]string{}
+	for _, namespace := range namespaces {
+		status := "Unknown"
+		//nolint:errcheck
+		d.withDeployment(namespace, func() error {
+			status, _, _ = d.r.GetReleaseStatus(d.r.HelmClient.GetConfiguration().ReleaseName)
+			return nil
+		})
+		rows = append(rows, []string{namespace, status})
+	}
+
+	d.app.QueueUpdateDraw(func() {
+		if err != nil {
+			d.message("Can't list deployments: %s", err)
+			return
+		}
+
+		fillTable(d.deployments, []string{"Name", "Status"}, rows)
+		if len(rows) == 0 {
+			d.message("No deployments, use the 'rasactl start' command to create one")
+			return
+		}
+
+		selected := d.getSelected()
+		if selected == "" {
+			selected = rows[0][0]
+			d.setSelected(selected)
+		}
+		for i, row := range rows {
+			if row[0] == selected {
+				d.deployments.Select(i+1, 0)
+			}
+		}
+	})
+}
+
+func (d *dashboard) refreshSelected() {
+	selected := d.getSelected()
+	if selected == "" {
+		return
+	}
+
+	details := new(bytes.Buffer)
+	podRows := [][]string{}
+	modelRows := [][]string{}
+	modelsMessage := ""
+
+	err := d.withDeployment(selected, func() error {
+		if err := d.r.printStatus(details); err != nil {
+			fmt.Fprintf(details, "Can't read the status: %s\n", err)
+		}
+
+		if pods, err := d.r.KubernetesClient.GetPods(); err == nil {
+			for _, pod := range pods.Items {
+				podRows = append(podRows, []string{
+					pod.Name,
+					d.r.KubernetesClient.PodStatus(pod.Status.Conditions),
+					string(pod.Status.Phase),
+				})
+			}
+		}
+
+		modelRows, modelsMessage = d.readModels()
+		return nil
+	})
+
+	d.app.QueueUpdateDraw(func() {
+		if selected != d.getSelected() {
+			return
+		}
+
+		if err != nil {
+			d.details.SetText(fmt.Sprintf("Can't read the %s deployment: %s", selected, err))
+			return
+		}
+
+		d.details.SetText(tview.TranslateANSI(details.String()))
+		fillTable(d.pods, []string{"Name", "Condition", "Status"}, podRows)
+		fillTable(d.models, []string{"Name", "Version", "Tags"}, modelRows)
+		if modelsMessage != "" {
+			d.models.SetTitle(fmt.Sprintf(" Models (%s) ", modelsMessage))
+		} else {
+			d.models.SetTitle(" Models ")
+		}
+	})
+}
+
+// readModels returns models for the selected deployment, or a message that explains why models can't be read.
+func (d *dashboard) readModels() ([][]string, string) {
+	r := d.r
+
+	isRunning, err := r.KubernetesClient.IsRasaXRunning()
+	if err != nil || !isRunning {
+		return nil, "the deployment is not running"
+	}
+
+	r.initRasaXClient()
+
+	token, err := r.getAuthToken()
+	if err != nil {
+		return nil, "use 'rasactl auth login' to see models"
+	}
+	r.RasaXClient.BearerToken = token
+
+	models, err := r.RasaXClient.ModelList()
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	rows := [][]string{}
+	for _, model := range models.Models {
+		rows = append(rows, []string{model.Model, model.Version, strings.Join(model.Tags, ",")})
+	}
+
+	return rows, ""
+}
+
+func fillTable(table *tview.Table, header []string, rows [][]string) {
+	row, _ := table.GetSelection()
+	table.Clear()
+
+	for i, title := range header {
+		table.SetCell(0, i, tview.NewTableCell(title).SetTextColor(tcell.ColorYellow).SetSelectable(false))
+	}
+	for i, data := range rows {
+		for j, value := range data {
+			table.SetCell(i+1, j, tview.NewTableCell(value).SetExpansion(1))
+		}
+	}
+
+	if row >= table.GetRowCount() {
+		row = table.GetRowCount() - 1
+	}
+	if row < 1 && len(rows) != 0 {
+		row = 1
+	}
+	table.Select(row, 0)
+}
+
+// selectedRow returns the value of the first column of the selected row.
+func selectedRow(table *tview.Table) string {
+	row, _ := table.GetSelection()
+	if row < 1 || row >= table.GetRowCount() {
+		return ""
+	}
+	return table.GetCell(row, 0).Text
+}
+
+// runInTerminal suspends the dashboard and runs a given action, the output of the action is visible in the terminal.
+func (d *dashboard) runInTerminal(description string, refresh chan struct{}, action func() error) {
+	deployment := d.getSelected()
+	d.stopLogs()
+
+	d.app.Suspend(func() {
+		fmt.Printf("%s: %s\n\n", deployment, description)
+
+		err := d.withDeployment(deployment, func() error {
+			defer d.r.Spinner.Stop()
+			return action()
+		})
+
+		if err != nil {
+			fmt.Printf("\nError: %s\n", err)
+		}
+
+		fmt.Print("\nPress Enter to return to the dashboard...")
+		//nolint:errcheck
+		bufio.NewReader(os.Stdin).ReadString('\n')
+	})
+
+	d.requestRefresh(refresh)
+}
+
+func (d *dashboard) startDeployment(refresh chan struct{}) {
+	if d.getSelected() == "" {
+		return
+	}
+
+	d.runInTerminal("starting the deployment", refresh, func() error {
+		_, isRunning, err := d.r.CheckDeploymentStatus()
+		if err != nil {
+			return err
+		}
+
+		if isRunning {
+			fmt.Printf("Rasa X is already running in the %s namespace.\n", d.r.Namespace)
+			return nil
+		}
+
+		if err := d.r.Start(); err != nil {
+			return err
+		}
+
+		if d.r.IsProjectSynced() {
+			fmt.Printf("The project is synced with the %s deployment, use the 'rasactl sync' command to sync further changes.\n", d.r.Namespace)
+		}
+		return nil
+	})
+}
+
+func (d *dashboard) stopDeployment(refresh chan struct{}) {
+	if d.getSelected() == "" {
+		return
+	}
+
+	d.runInTerminal("stopping the deployment", refresh, func() error {
+		_, isRunning, err := d.r.CheckDeploymentStatus()
+		if err != nil {
+			return err
+		}
+
+		if !isRunning {
+			fmt.Printf("The %s deployment is not running.\n", d.r.Namespace)
+			return nil
+		}
+
+		return d.r.Stop()
+	})
+}
+
+func (d *dashboard) openDeployment() {
+	if d.getSelected() == "" {
+		return
+	}
+
+	deployment := d.getSelected()
+	go func() {
+		url := ""
+		err := d.withDeployment(deployment, func() error {
+			var err error
+			url, err = d.r.GetRasaXURL()
+			return err
+		})
+
+		if err == nil {
+			err = browser.OpenURL(url)
+		}
+
+		d.app.QueueUpdateDraw(func() {
+			if err != nil {
+				d.message("Can't open the URL using a web browser, go to the URL manually: %s", url)
+				return
+			}
+			d.message("The %s URL has been opened in your web browser", url)
+		})
+	}()
+}
+
+// tailLogs streams logs of the selected pod into the logs panel.
+func (d *dashboard) tailLogs() {
+	pod := selectedRow(d.pods)
+	if pod == "" {
+		d.message("Select a pod in the pods panel to see logs")
+		return
+	}
+
+	d.stopLogs()
+	d.logs.Clear()
+	d.logs.SetTitle(fmt.Sprintf(" Logs: %s ", pod))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancelLogs = cancel
+
+	deployment := d.getSelected()
+	go func() {
+		r := d.r
+		var stream io.ReadCloser
+		err := d.withDeployment(deployment, func() error {
+			logsFlags := r.Flags.Logs
+			r.Flags.Logs = types.RasaCtlLogsFlags{Follow: true, TailLines: uiLogsTailLines}
+			defer func() { r.Flags.Logs = logsFlags }()
+
+			// Show logs of the first container if a pod has more than one.
+			if podData, err := r.KubernetesClient.GetPod(pod); err == nil && len(podData.Spec.Containers) > 1 {
+				r.Flags.Logs.Container = podData.Spec.Containers[0].Name
+			}
+
+			var err error
+			stream, err = r.KubernetesClient.GetLogs(pod).Stream(ctx)
+			return err
+		})
+
+		if err != nil {
+			fmt.Fprintf(d.logs, "Can't read logs: %s\n", err)
+			return
+		}
+		defer stream.Close()
+
+		//nolint:errcheck
+		io.Copy(d.logs, stream)
+	}()
+}
+
+func (d *dashboard) stopLogs() {
+	if d.cancelLogs != nil {
+		d.cancelLogs()
+		d.cancelLogs = nil
+	}
+}
+
+func (d *dashboard) tagModel(refresh chan struct{}) {
+	model := selectedRow(d.models)
+	if model == "" {
+		d.message("Select a model in the models panel to tag it")
+		return
+	}
+
+	tag := "production"
+	form := tview.NewForm().
+		AddInputField("Tag", "production", 30, nil, func(text string) { tag = text })
+	form.AddButton("Tag", func() {
+		d.pages.RemovePage(uiModalPage)
+		if tag == "" {
+			return
+		}
+		d.runInTerminal(fmt.Sprintf("tagging the %s model as %s", model, tag), refresh, func() error {
+			return d.r.ModelTag(model, tag)
+		})
+	})
+	form.AddButton("Cancel", func() {
+		d.pages.RemovePage(uiModalPage)
+	})
+	form.SetCancelFunc(func() {
+		d.pages.RemovePage(uiModalPage)
+	})
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Tag the %s model ", model))
+
+	d.pages.AddPage(uiModalPage, centered(form, 60, 7), true, true)
+}
+
+func (d *dashboard) deleteModel(refresh chan struct{}) {
+	model := selectedRow(d.models)
+	if model == "" {
+		d.message("Select a model in the models panel to delete it")
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Delete the %s model?", model)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			d.pages.RemovePage(uiModalPage)
+			if buttonLabel != "Delete" {
+				return
+			}
+			d.runInTerminal(fmt.Sprintf("deleting the %s model", model), refresh, func() error {
+				d.r.Flags.Model.Delete.Name = model
+				return d.r.ModelDelete()
+			})
+		})
+
+	d.pages.AddPage(uiModalPage, modal, true, true)
+}
+
+// centered returns a primitive placed in the center of the screen.
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
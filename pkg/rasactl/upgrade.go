@@ -24,11 +24,19 @@ func (r *RasaCtl) Upgrade() error {
 		return err
 	}
 
+	if r.isDryRun() {
+		return r.dryRun()
+	}
+
 	// Init Rasa X client
 	r.initRasaXClient()
 
-	r.Spinner.Message("Upgrading Rasa X")
-	if err := r.HelmClient.Upgrade(); err != nil {
+	msg := "Upgrading Rasa X"
+	r.Spinner.Message(msg)
+	stopProgress := r.watchDeploymentProgress(msg)
+	err := r.HelmClient.Upgrade()
+	stopProgress()
+	if err != nil {
 		return err
 	}
 
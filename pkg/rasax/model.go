@@ -17,6 +17,8 @@ package rasax
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +27,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/schollz/progressbar/v3"
 	"golang.org/x/xerrors"
@@ -32,64 +35,167 @@ import (
 	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
 )
 
-func (r *RasaX) ModelUpload() error {
-	file, err := os.Open(r.Flags.Model.Upload.File)
-	stat, _ := file.Stat()
+const (
+	// modelUploadAttempts defines how many times a model upload is attempted.
+	modelUploadAttempts = 5
+
+	// modelUploadBackoff defines the delay before the first retry, the delay is doubled after each retry.
+	modelUploadBackoff = time.Second
+
+	// modelUploadMaxBackoff defines the maximum delay between retries.
+	modelUploadMaxBackoff = time.Second * 30
+)
+
+// ModelUpload uploads a given model file to Rasa X, the upload is canceled after the timeout if it's greater than 0.
+// The model file is streamed, and the upload is retried with a backoff
+// if it fails because of a connection error or a server error.
+func (r *RasaX) ModelUpload(path string, timeout time.Duration) error {
+	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	bar := r.progressBarBytes(
-		stat.Size(),
-		fmt.Sprintf("Sending %s", filepath.Base(file.Name())),
-	)
-
-	buffer := new(bytes.Buffer)
-	body := progressbar.NewReader(buffer, bar)
-	writer := multipart.NewWriter(buffer)
-	part, err := writer.CreateFormFile("model", filepath.Base(file.Name()))
+	stat, err := file.Stat()
 	if err != nil {
 		return err
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
-		return err
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	writer.Close()
-	//buffer
-	urlAddress := r.getURL()
-	url := fmt.Sprintf("%s/api/projects/default/models", urlAddress)
-	r.Log.V(1).Info("Sending a request to Rasa X", "url", url)
-	request, err := http.NewRequest("POST", url, &body)
+	url := fmt.Sprintf("%s/api/projects/default/models", r.getURL())
+	backoff := modelUploadBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := r.sendModel(ctx, url, file, stat.Size())
+		if err == nil || !retry {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return xerrors.Errorf("can't upload the model in %s: %w", timeout, err)
+		}
+
+		if attempt == modelUploadAttempts {
+			return xerrors.Errorf("can't upload the model after %d attempts: %w", attempt, err)
+		}
+
+		r.Log.Info("Can't upload the model, retrying", "attempt", attempt, "backoff", backoff, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return xerrors.Errorf("can't upload the model in %s: %w", timeout, err)
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > modelUploadMaxBackoff {
+			backoff = modelUploadMaxBackoff
+		}
+	}
+}
+
+// sendModel sends a model file to Rasa X, the multipart body is streamed through a pipe
+// and the SHA-256 checksum of the model is computed while the file is sent.
+// It returns true if an error is transient and the upload can be retried.
+func (r *RasaX) sendModel(ctx context.Context, url string, file *os.File, size int64) (bool, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	name := filepath.Base(file.Name())
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	form := multipart.NewWriter(writer)
+	contentLength, err := multipartLength(form.Boundary(), name, size)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	request.Header.Add("Content-Type", writer.FormDataContentType())
+	var model io.Reader = file
+	if bar := r.progressBarBytes(size, fmt.Sprintf("Sending %s", name)); bar != nil {
+		defer bar.Clear() //nolint:errcheck
+		progress := progressbar.NewReader(file, bar)
+		model = &progress
+	}
+
+	checksum := sha256.New()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		writer.CloseWithError(writeModelForm(form, name, io.TeeReader(model, checksum))) //nolint:errcheck
+	}()
+
+	r.Log.V(1).Info("Sending a request to Rasa X", "url", url, "contentLength", contentLength)
+	request, err := http.NewRequestWithContext(ctx, "POST", url, reader)
+	if err != nil {
+		return false, err
+	}
+
+	request.ContentLength = contentLength
+	request.Header.Add("Content-Type", form.FormDataContentType())
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.BearerToken))
 	client := &http.Client{}
 
 	response, err := client.Do(request)
+	// Stop writing the body if the request has failed before the whole body has been sent.
+	reader.Close()
+	<-done
 	if err != nil {
-		return err
+		return true, err
 	}
 	defer response.Body.Close()
 
-	switch response.StatusCode {
-	case 201:
-		fmt.Println("Successfully uploaded.")
-	case 401:
-		return xerrors.Errorf("unauthorized, use the 'rasactl auth login' command to authorized")
-	case 409:
+	switch {
+	case response.StatusCode == 201:
+		r.Log.V(1).Info("The model has been uploaded", "sha256", fmt.Sprintf("%x", checksum.Sum(nil)))
+		fmt.Printf("Successfully uploaded (sha256: %x).\n", checksum.Sum(nil))
+	case response.StatusCode == 401:
+		return false, xerrors.Errorf("unauthorized, use the 'rasactl auth login' command to authorized")
+	case response.StatusCode == 409:
 		fmt.Println("A model with that name already exists.")
+	case response.StatusCode >= 500:
+		content, _ := ioutil.ReadAll(response.Body)
+		return true, xerrors.Errorf("Rasa X has returned status code %s: %s", response.Status, content)
 	default:
 		content, _ := ioutil.ReadAll(response.Body)
-		return xerrors.Errorf("%s", content)
+		return false, xerrors.Errorf("%s", content)
 	}
 
-	return nil
+	return false, nil
+}
+
+// writeModelForm writes a multipart form with a given model.
+func writeModelForm(form *multipart.Writer, name string, model io.Reader) error {
+	part, err := form.CreateFormFile("model", name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, model); err != nil {
+		return err
+	}
+
+	return form.Close()
+}
+
+// multipartLength returns the length of a multipart form written by writeModelForm for a model of a given size.
+func multipartLength(boundary, name string, size int64) (int64, error) {
+	buffer := new(bytes.Buffer)
+	form := multipart.NewWriter(buffer)
+	if err := form.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+
+	if err := writeModelForm(form, name, bytes.NewReader(nil)); err != nil {
+		return 0, err
+	}
+
+	return int64(buffer.Len()) + size, nil
 }
 
 func (r *RasaX) ModelDownload() error {
@@ -185,9 +291,10 @@ func (r *RasaX) ModelList() (*rtypes.ModelsListEndpointResponse, error) {
 	}
 }
 
-func (r *RasaX) ModelTag() error {
+// ModelTag tags a given model.
+func (r *RasaX) ModelTag(model, tag string) error {
 	urlAddress := r.getURL()
-	url := fmt.Sprintf("%s/api/projects/default/models/%s/tags/%s", urlAddress, r.Flags.Model.Tag.Model, r.Flags.Model.Tag.Name)
+	url := fmt.Sprintf("%s/api/projects/default/models/%s/tags/%s", urlAddress, model, tag)
 	r.Log.V(1).Info("Sending a request to Rasa X", "url", url)
 	request, err := http.NewRequest("PUT", url, nil)
 	if err != nil {
@@ -208,7 +315,7 @@ func (r *RasaX) ModelTag() error {
 		fmt.Println("Model has been tagged successfully.")
 		return nil
 	case 404:
-		return xerrors.Errorf("model '%s' not found", r.Flags.Model.Tag.Model)
+		return xerrors.Errorf("model '%s' not found", model)
 	case 401:
 		return xerrors.Errorf("unauthorized, use the 'rasactl auth login' command to authorized")
 	default:
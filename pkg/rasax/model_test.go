@@ -0,0 +1,148 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package rasax
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+// newModelUploadTest returns a Rasa X client that uploads models to a server with a given handler
+// for model uploads, a path to a test model, and the content of the model.
+func newModelUploadTest(t *testing.T, handler http.HandlerFunc) (*RasaX, string, []byte) {
+	model := bytes.Repeat([]byte("model"), 100000)
+	file := filepath.Join(t.TempDir(), "model.tar.gz")
+	require.NoError(t, os.WriteFile(file, model, 0644))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Requests other than model uploads check if the URL is accessible.
+		if req.Method != http.MethodPost {
+			return
+		}
+		require.Equal(t, "/api/projects/default/models", req.URL.Path)
+		handler(w, req)
+	}))
+	t.Cleanup(server.Close)
+
+	return &RasaX{URL: server.URL, Flags: &types.RasaCtlFlags{}, Log: logr.Discard()}, file, model
+}
+
+func TestModelUploadStreamsModel(t *testing.T) {
+	var uploaded []byte
+	var contentLength int64
+	var bodyLength int
+
+	client, file, model := newModelUploadTest(t, func(w http.ResponseWriter, req *http.Request) {
+		contentLength = req.ContentLength
+		body, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodyLength = len(body)
+
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		file, header, err := req.FormFile("model")
+		require.NoError(t, err)
+		defer file.Close()
+		require.Equal(t, "model.tar.gz", header.Filename)
+
+		uploaded, err = ioutil.ReadAll(file)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	require.NoError(t, client.ModelUpload(file, 0))
+	require.Equal(t, model, uploaded)
+	require.Equal(t, int64(bodyLength), contentLength)
+}
+
+func TestModelUploadRetries(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(w http.ResponseWriter)
+	}{
+		{
+			name: "server error",
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "dropped connection",
+			fail: func(w http.ResponseWriter) {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				conn.Close()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int32
+			client, file, _ := newModelUploadTest(t, func(w http.ResponseWriter, req *http.Request) {
+				if atomic.AddInt32(&requests, 1) == 1 {
+					test.fail(w)
+					return
+				}
+				//nolint:errcheck
+				ioutil.ReadAll(req.Body)
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			require.NoError(t, client.ModelUpload(file, 0))
+			require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestModelUploadDoesNotRetryClientErrors(t *testing.T) {
+	var requests int32
+	client, file, _ := newModelUploadTest(t, func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("bad request")) //nolint:errcheck
+	})
+
+	err := client.ModelUpload(file, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad request")
+	require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestModelUploadTimeout(t *testing.T) {
+	// The server doesn't respond until the test is finished.
+	release := make(chan struct{})
+	client, file, _ := newModelUploadTest(t, func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+
+	start := time.Now()
+	err := client.ModelUpload(file, time.Millisecond*200)
+	require.Error(t, err)
+	require.Less(t, int64(time.Since(start)), int64(time.Second*5))
+}
@@ -16,20 +16,117 @@ limitations under the License.
 package status
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
+	"text/template"
 
-	"github.com/RasaHQ/rasactl/pkg/utils"
+	"golang.org/x/xerrors"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
 )
 
-// PrintOutput returns string based on the output format.
-func PrintOutput(data [][]string, format string) {
-	switch format {
-	case "table":
-		PrintTableNoHeader(data)
-	case "json":
-		output, _ := utils.StringSliceToJSON(data)
-		fmt.Println(output)
+const (
+	// OutputFormats is a description of supported output formats, used in flag descriptions.
+	OutputFormats = "table|json|yaml|jsonpath=<template>|go-template=<template>"
+
+	jsonPathPrefix   = "jsonpath="
+	goTemplatePrefix = "go-template="
+)
+
+// ValidateOutputFormat checks if a given output format is supported.
+func ValidateOutputFormat(format string) error {
+	switch {
+	case format == "table", format == "json", format == "yaml":
+		return nil
+	case strings.HasPrefix(format, jsonPathPrefix):
+		_, err := parseJSONPath(strings.TrimPrefix(format, jsonPathPrefix))
+		return err
+	case strings.HasPrefix(format, goTemplatePrefix):
+		_, err := parseGoTemplate(strings.TrimPrefix(format, goTemplatePrefix))
+		return err
+	}
+
+	return xerrors.Errorf("invalid output format: %s, use one of: %s", format, OutputFormats)
+}
+
+// IsTableOutput returns true if data should be printed as a table.
+func IsTableOutput(format string) bool {
+	return format == "" || format == "table"
+}
+
+// FprintObject writes a given object to w in a structured output format.
+// The JSON representation of the object is used for all formats, jsonpath and go-template
+// expressions refer to JSON field names.
+func FprintObject(w io.Writer, obj interface{}, format string) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case format == "json":
+		_, err := fmt.Fprintln(w, string(data))
+		return err
+	case format == "yaml":
+		output, err := yaml.JSONToYAML(data)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(output)
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasPrefix(format, jsonPathPrefix):
+		jp, err := parseJSONPath(strings.TrimPrefix(format, jsonPathPrefix))
+		if err != nil {
+			return err
+		}
+		if err := jp.Execute(w, value); err != nil {
+			return err
+		}
+	case strings.HasPrefix(format, goTemplatePrefix):
+		tmpl, err := parseGoTemplate(strings.TrimPrefix(format, goTemplatePrefix))
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(w, value); err != nil {
+			return err
+		}
 	default:
-		PrintTableNoHeader(data)
+		return xerrors.Errorf("invalid output format: %s, use one of: %s", format, OutputFormats)
 	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+func parseJSONPath(expr string) (*jsonpath.JSONPath, error) {
+	// Accept expressions without braces, e.g. '.name', the same as kubectl.
+	if !strings.Contains(expr, "{") {
+		expr = fmt.Sprintf("{%s}", expr)
+	}
+
+	jp := jsonpath.New("output").AllowMissingKeys(true)
+	if err := jp.Parse(expr); err != nil {
+		return nil, xerrors.Errorf("invalid jsonpath template %q: %w", expr, err)
+	}
+
+	return jp, nil
+}
+
+func parseGoTemplate(expr string) (*template.Template, error) {
+	tmpl, err := template.New("output").Parse(expr)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid go-template %q: %w", expr, err)
+	}
+
+	return tmpl, nil
 }
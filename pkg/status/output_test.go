@@ -0,0 +1,86 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package status_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+var _ = Describe("Output", func() {
+
+	list := types.DeploymentListOutput{
+		Deployments: []types.DeploymentListItemOutput{
+			{Current: true, Name: "first", Status: "Running", Version: "1.0.0"},
+			{Name: "second", Status: "Stopped", Version: "0.42.6"},
+		},
+	}
+
+	It("validates output formats", func() {
+		Expect(status.ValidateOutputFormat("table")).To(Succeed())
+		Expect(status.ValidateOutputFormat("json")).To(Succeed())
+		Expect(status.ValidateOutputFormat("yaml")).To(Succeed())
+		Expect(status.ValidateOutputFormat("jsonpath={.deployments[*].name}")).To(Succeed())
+		Expect(status.ValidateOutputFormat("go-template={{.name}}")).To(Succeed())
+
+		Expect(status.ValidateOutputFormat("xml")).ToNot(Succeed())
+		Expect(status.ValidateOutputFormat("jsonpath={.deployments[")).ToNot(Succeed())
+		Expect(status.ValidateOutputFormat("go-template={{.name")).ToNot(Succeed())
+	})
+
+	It("prints an object in the JSON format", func() {
+		buf := new(bytes.Buffer)
+		Expect(status.FprintObject(buf, list.Deployments[1], "json")).To(Succeed())
+		Expect(buf.String()).To(MatchJSON(`{
+			"current": false,
+			"name": "second",
+			"status": "Stopped",
+			"rasa_production_version": "",
+			"rasa_worker_version": "",
+			"enterprise": "",
+			"version": "0.42.6"
+		}`))
+	})
+
+	It("prints an object in the YAML format", func() {
+		buf := new(bytes.Buffer)
+		Expect(status.FprintObject(buf, list, "yaml")).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("deployments:\n- current: true\n"))
+		Expect(buf.String()).To(ContainSubstring("  name: second\n"))
+	})
+
+	It("prints an object using a jsonpath template", func() {
+		buf := new(bytes.Buffer)
+		Expect(status.FprintObject(buf, list, "jsonpath={.deployments[*].name}")).To(Succeed())
+		Expect(buf.String()).To(Equal("first second\n"))
+
+		buf.Reset()
+		Expect(status.FprintObject(buf, list, "jsonpath=.deployments[0].status")).To(Succeed())
+		Expect(buf.String()).To(Equal("Running\n"))
+	})
+
+	It("prints an object using a go template", func() {
+		buf := new(bytes.Buffer)
+		tmpl := `go-template={{range .deployments}}{{.name}}={{.version}};{{end}}`
+		Expect(status.FprintObject(buf, list, tmpl)).To(Succeed())
+		Expect(buf.String()).To(Equal("first=1.0.0;second=0.42.6;\n"))
+	})
+})
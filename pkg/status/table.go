@@ -16,6 +16,7 @@ limitations under the License.
 package status
 
 import (
+	"io"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -23,7 +24,12 @@ import (
 
 // PrintTable prints a table in the terminal.
 func PrintTable(header []string, data [][]string) {
-	table := tablewriter.NewWriter(os.Stdout)
+	FprintTable(os.Stdout, header, data)
+}
+
+// FprintTable writes a table to w.
+func FprintTable(w io.Writer, header []string, data [][]string) {
+	table := tablewriter.NewWriter(w)
 	table.SetHeader(header)
 	table.SetAutoWrapText(false)
 	table.SetAutoFormatHeaders(true)
@@ -42,7 +48,12 @@ func PrintTable(header []string, data [][]string) {
 
 // PrintTableNoHeader prints a table without headers in the terminal.
 func PrintTableNoHeader(data [][]string) {
-	table := tablewriter.NewWriter(os.Stdout)
+	FprintTableNoHeader(os.Stdout, data)
+}
+
+// FprintTableNoHeader writes a table without headers to w.
+func FprintTableNoHeader(w io.Writer, data [][]string) {
+	table := tablewriter.NewWriter(w)
 	table.SetAutoWrapText(false)
 	table.SetAutoFormatHeaders(true)
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -3,16 +3,83 @@ package status
 import (
 	"fmt"
 
+	"github.com/Masterminds/semver/v3"
+	"golang.org/x/xerrors"
+
 	"github.com/RasaHQ/rasactl/pkg/types"
 	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
 	"github.com/RasaHQ/rasactl/pkg/utils"
 )
 
+// rasaVersionCompatibility maps Rasa X versions to compatible Rasa versions,
+// the version field stores a Rasa version that is used if a version is not defined.
+var rasaVersionCompatibility = []struct {
+	rasaX   string
+	rasa    string
+	version string
+}{
+	{rasaX: ">= 1.0.0", rasa: "~2.8.0", version: "2.8.15"},
+	{rasaX: "~0.42.0", rasa: "~2.8.0", version: "2.8.15"},
+	{rasaX: "~0.41.0", rasa: "~2.7.0", version: "2.7.2"},
+	{rasaX: "~0.40.0", rasa: "~2.6.0", version: "2.6.3"},
+	{rasaX: "~0.39.0", rasa: "~2.5.0", version: "2.5.2"},
+}
+
+// CompatibleRasaVersion returns a Rasa version compatible with a given Rasa X version.
+// If rasaVersion is not empty, it's checked against the Rasa X version and returned.
+// A version can't be checked for a Rasa X version that is not a semantic version, e.g. the latest edge release,
+// in such a case rasaVersion is returned as it is.
+func CompatibleRasaVersion(rasaXVersion, rasaVersion string) (string, error) {
+	if _, err := semver.NewVersion(rasaXVersion); err != nil {
+		return rasaVersion, nil
+	}
+
+	for _, c := range rasaVersionCompatibility {
+		if !utils.RasaXVersionConstrains(rasaXVersion, c.rasaX) {
+			continue
+		}
+
+		if rasaVersion == "" {
+			return c.version, nil
+		}
+
+		if !utils.RasaXVersionConstrains(rasaVersion, c.rasa) {
+			return "", xerrors.Errorf("Rasa %s is not compatible with Rasa X %s, use Rasa in version %s", rasaVersion, rasaXVersion, c.rasa)
+		}
+
+		return rasaVersion, nil
+	}
+
+	if rasaVersion == "" {
+		return "", xerrors.Errorf("can't determine a Rasa version compatible with Rasa X %s, define a Rasa version", rasaXVersion)
+	}
+
+	return rasaVersion, nil
+}
+
 func checkVersionConstrains(version *rtypes.VersionEndpointResponse, flags *types.RasaCtlFlags) {
 
 	if flags.Start.Project || flags.Start.ProjectPath != "" {
 		localProjectRasaXVersion(version)
 	}
+
+	if notice := rasaServerVersionNotice(version); notice != "" {
+		YellowBox("Notice", notice)
+	}
+}
+
+// rasaServerVersionNotice checks if the Rasa production version is compatible with Rasa X,
+// it returns a notice if it's not. Rasa X reports the 0.0.0 version if a Rasa server is not connected.
+func rasaServerVersionNotice(version *rtypes.VersionEndpointResponse) string {
+	if version.Rasa.Production == "" || version.Rasa.Production == "0.0.0" {
+		return ""
+	}
+
+	if _, err := CompatibleRasaVersion(version.RasaX, version.Rasa.Production); err != nil {
+		return fmt.Sprintf("The Rasa production server might not work correctly with Rasa X: %s", err)
+	}
+
+	return ""
 }
 
 func localProjectRasaXVersion(version *rtypes.VersionEndpointResponse) {
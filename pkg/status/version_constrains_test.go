@@ -0,0 +1,64 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package status_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/status"
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
+)
+
+var _ = Describe("Version constrains", func() {
+
+	It("returns a Rasa version compatible with Rasa X", func() {
+		Expect(status.CompatibleRasaVersion("1.0.1", "")).To(Equal("2.8.15"))
+		Expect(status.CompatibleRasaVersion("0.41.2", "")).To(Equal("2.7.2"))
+	})
+
+	It("checks a defined Rasa version", func() {
+		Expect(status.CompatibleRasaVersion("1.0.1", "2.8.3")).To(Equal("2.8.3"))
+
+		_, err := status.CompatibleRasaVersion("1.0.1", "2.7.0")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("doesn't check a version for Rasa X that is not a semantic version", func() {
+		Expect(status.CompatibleRasaVersion("latest", "")).To(BeEmpty())
+		Expect(status.CompatibleRasaVersion("latest", "2.8.3")).To(Equal("2.8.3"))
+	})
+
+	It("requires a Rasa version for an unknown Rasa X version", func() {
+		_, err := status.CompatibleRasaVersion("0.30.0", "")
+		Expect(err).To(HaveOccurred())
+
+		Expect(status.CompatibleRasaVersion("0.30.0", "1.10.2")).To(Equal("1.10.2"))
+	})
+
+	It("checks the version of a connected Rasa production server", func() {
+		version := func(rasaX, production string) *rtypes.VersionEndpointResponse {
+			return &rtypes.VersionEndpointResponse{RasaX: rasaX, Rasa: rtypes.RasaSpec{Production: production}}
+		}
+
+		Expect(status.RasaServerVersionNotice(version("1.0.1", "2.8.3"))).To(BeEmpty())
+		Expect(status.RasaServerVersionNotice(version("1.0.1", "2.7.0"))).NotTo(BeEmpty())
+
+		// A Rasa server is not connected.
+		Expect(status.RasaServerVersionNotice(version("1.0.1", ""))).To(BeEmpty())
+		Expect(status.RasaServerVersionNotice(version("1.0.1", "0.0.0"))).To(BeEmpty())
+	})
+})
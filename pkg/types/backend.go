@@ -0,0 +1,27 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+// BackendType defines where rasactl runs Rasa X deployments.
+type BackendType string
+
+const (
+	// BackendKubernetes runs deployments in a Kubernetes cluster by using the rasa-x helm chart.
+	BackendKubernetes BackendType = "kubernetes"
+
+	// BackendDocker runs deployments as Docker containers on the local machine.
+	BackendDocker BackendType = "docker"
+)
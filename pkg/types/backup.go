@@ -0,0 +1,50 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"time"
+
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
+)
+
+const (
+	// BackupMetadataFile is a name of the file that stores backup metadata.
+	BackupMetadataFile string = "rasactl-backup.yaml"
+
+	// BackupValuesFile is a name of the file that stores helm values.
+	BackupValuesFile string = "values.yaml"
+
+	// BackupStateFile is a name of the file that stores the rasactl state.
+	BackupStateFile string = "state.yaml"
+
+	// BackupDatabasesDir is a name of the directory that stores database dumps.
+	BackupDatabasesDir string = "postgresql"
+
+	// BackupModelsDir is a name of the directory that stores models.
+	BackupModelsDir string = "models"
+)
+
+// BackupMetadata stores information about a deployment backup.
+type BackupMetadata struct {
+	Deployment       string             `json:"deployment"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	RasaCtlVersion   string             `json:"rasactlVersion"`
+	HelmChartVersion string             `json:"helmChartVersion"`
+	RasaXVersion     string             `json:"rasaXVersion"`
+	Databases        []string           `json:"databases"`
+	Models           []rtypes.ModelSpec `json:"models"`
+}
@@ -0,0 +1,38 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import "time"
+
+const (
+	// BundleMetadataFile is a name of the file that stores bundle metadata.
+	BundleMetadataFile string = "rasactl-bundle.yaml"
+
+	// BundleChartDir is a name of the directory that stores the helm chart.
+	BundleChartDir string = "chart"
+
+	// BundleImagesFile is a name of the file that stores container images in the docker save format.
+	BundleImagesFile string = "images.tar"
+)
+
+// BundleMetadata stores information about an air-gapped bundle.
+type BundleMetadata struct {
+	CreatedAt        time.Time `json:"createdAt"`
+	RasaCtlVersion   string    `json:"rasactlVersion"`
+	HelmChart        string    `json:"helmChart"`
+	HelmChartVersion string    `json:"helmChartVersion"`
+	Images           []string  `json:"images"`
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	rtypes "github.com/RasaHQ/rasactl/pkg/types/rasax"
+)
+
+// DeploymentSpec stores a declarative specification of a deployment.
+// The specification is used by the 'rasactl apply' and 'rasactl diff' commands.
+type DeploymentSpec struct {
+	// Name is a deployment name.
+	Name string `json:"name"`
+
+	// Chart defines the rasa-x helm chart used by the deployment.
+	Chart DeploymentChartSpec `json:"chart,omitempty"`
+
+	// ValuesFile is a path to the helm values file.
+	ValuesFile string `json:"valuesFile,omitempty"`
+
+	// Values stores helm values, the values are merged with values from the values file.
+	Values map[string]interface{} `json:"values,omitempty"`
+
+	// ProjectPath is a path to a Rasa project directory mounted in kind.
+	ProjectPath string `json:"projectPath,omitempty"`
+
+	// Enterprise defines a reference to an Enterprise license.
+	Enterprise *DeploymentEnterpriseSpec `json:"enterprise,omitempty"`
+
+	// Environments defines Rasa X / Enterprise deployment environments.
+	Environments []rtypes.EnvironmentsEndpointRequest `json:"environments,omitempty"`
+
+	// Models defines models to upload.
+	Models []DeploymentModelSpec `json:"models,omitempty"`
+}
+
+// DeploymentChartSpec stores information about the helm chart.
+type DeploymentChartSpec struct {
+	ReleaseName string `json:"releaseName,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// DeploymentEnterpriseSpec stores a reference to an Enterprise license.
+// The license is read from a file or an environment variable.
+type DeploymentEnterpriseSpec struct {
+	LicenseFile string `json:"licenseFile,omitempty"`
+	LicenseEnv  string `json:"licenseEnv,omitempty"`
+}
+
+// DeploymentModelSpec stores a model to upload and its tags.
+type DeploymentModelSpec struct {
+	File string   `json:"file"`
+	Tags []string `json:"tags,omitempty"`
+}
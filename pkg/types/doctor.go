@@ -0,0 +1,45 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+// DoctorCheckStatus defines a result of a diagnostic check.
+type DoctorCheckStatus string
+
+const (
+	// DoctorCheckPass indicates that a check passed.
+	DoctorCheckPass DoctorCheckStatus = "pass"
+
+	// DoctorCheckWarn indicates that a check found a problem that limits some features.
+	DoctorCheckWarn DoctorCheckStatus = "warn"
+
+	// DoctorCheckFail indicates that a check found a problem that prevents rasactl from working.
+	DoctorCheckFail DoctorCheckStatus = "fail"
+)
+
+// DoctorCheck stores a result of a diagnostic check executed by the 'rasactl doctor' command.
+type DoctorCheck struct {
+	// Name is a name of the check.
+	Name string `json:"name"`
+
+	// Status is a result of the check.
+	Status DoctorCheckStatus `json:"status"`
+
+	// Message describes the result.
+	Message string `json:"message"`
+
+	// Hint describes how to fix a problem found by the check.
+	Hint string `json:"hint,omitempty"`
+}
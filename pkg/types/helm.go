@@ -23,12 +23,17 @@ const (
 
 	// HelmChartVersionRasaX storage a version of helm chart used to deploy Rasa X / Enterprise.
 	HelmChartVersionRasaX string = "4.3.3"
+
+	// HelmRepositoryURLRasaX stores a URL of the default helm repository with the rasa-x helm chart.
+	HelmRepositoryURLRasaX string = "https://rasahq.github.io/rasa-x-helm"
 )
 
 // RepositorySpec stores data related to a helm repository.
 type RepositorySpec struct {
-	Name string
-	URL  string
+	Name     string `mapstructure:"name"`
+	URL      string `mapstructure:"url"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
 }
 
 // HelmConfigurationSpec stores a configuration for the helm client.
@@ -40,4 +45,6 @@ type HelmConfigurationSpec struct {
 	StartProject bool
 	Atomic       bool
 	Wait         bool
+	DryRun       bool
+	ClientOnly   bool
 }
@@ -26,3 +26,24 @@ const (
 	// remotely. The remote type means that external IP address is used to connect to the Kubernetes API.
 	KubernetesBackendRemote KubernetesBackendType = "remote"
 )
+
+// LocalClusterType defines a type of a local Kubernetes cluster that is used as a backend.
+type LocalClusterType string
+
+const (
+	// LocalClusterUnknown indicates that the current Kubernetes cluster is not a local cluster
+	// supported by rasactl.
+	LocalClusterUnknown LocalClusterType = ""
+
+	// LocalClusterKind indicates a cluster created with kind.
+	LocalClusterKind LocalClusterType = "kind"
+
+	// LocalClusterK3d indicates a cluster created with k3d.
+	LocalClusterK3d LocalClusterType = "k3d"
+
+	// LocalClusterMinikube indicates a cluster created with minikube.
+	LocalClusterMinikube LocalClusterType = "minikube"
+)
+
+// SyncPodLabel is a label of the helper pod that syncs a local project into a persistent volume.
+const SyncPodLabel string = "rasactl-sync"
@@ -0,0 +1,114 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import "time"
+
+// The types below define the schema of structured output (-o json|yaml|jsonpath|go-template)
+// for read commands. Field names are part of the public interface, don't rename them.
+
+// DeploymentStatusOutput stores status of a deployment, it's used by the 'rasactl status' command.
+type DeploymentStatusOutput struct {
+	Name                  string `json:"name"`
+	Status                string `json:"status"`
+	URL                   string `json:"url"`
+	Version               string `json:"version"`
+	Enterprise            string `json:"enterprise"`
+	RasaProductionVersion string `json:"rasa_production_version,omitempty"`
+	RasaWorkerVersion     string `json:"rasa_worker_version,omitempty"`
+	DatabaseMigration     string `json:"database_migration,omitempty"`
+	ProjectPath           string `json:"project_path"`
+
+	// The fields below are set if the --details flag is used.
+	HelmChart         string            `json:"helm_chart,omitempty"`
+	HelmRelease       string            `json:"helm_release,omitempty"`
+	HelmReleaseStatus string            `json:"helm_release_status,omitempty"`
+	Pods              []PodStatusOutput `json:"pods,omitempty"`
+}
+
+// PodStatusOutput stores status of a pod.
+type PodStatusOutput struct {
+	Name      string `json:"name"`
+	Condition string `json:"condition"`
+	Status    string `json:"status"`
+}
+
+// DeploymentListOutput stores a list of deployments, it's used by the 'rasactl list' command.
+type DeploymentListOutput struct {
+	Deployments []DeploymentListItemOutput `json:"deployments"`
+}
+
+// DeploymentListItemOutput stores a deployment summary.
+type DeploymentListItemOutput struct {
+	Current               bool   `json:"current"`
+	Name                  string `json:"name"`
+	Status                string `json:"status"`
+	RasaProductionVersion string `json:"rasa_production_version"`
+	RasaWorkerVersion     string `json:"rasa_worker_version"`
+	Enterprise            string `json:"enterprise"`
+	Version               string `json:"version"`
+}
+
+// ModelListOutput stores a list of models, it's used by the 'rasactl model list' command.
+type ModelListOutput struct {
+	Models []ModelOutput `json:"models"`
+}
+
+// ModelOutput stores information about a model.
+type ModelOutput struct {
+	Name       string    `json:"name"`
+	Version    string    `json:"version"`
+	Compatible bool      `json:"compatible"`
+	Tags       []string  `json:"tags"`
+	Hash       string    `json:"hash"`
+	TrainedAt  time.Time `json:"trained_at"`
+}
+
+// AuthStatusOutput stores authentication state for a deployment, it's used by the 'rasactl auth status' command.
+type AuthStatusOutput struct {
+	Deployment string `json:"deployment"`
+	LoggedIn   bool   `json:"logged_in"`
+
+	// Source defines where credentials come from, one of: environment, credentials-store, none.
+	Source   string `json:"source"`
+	Username string `json:"username,omitempty"`
+}
+
+// EnterpriseStatusOutput stores Enterprise license state, it's used by the 'rasactl enterprise status' command.
+type EnterpriseStatusOutput struct {
+	Deployment   string `json:"deployment"`
+	Active       bool   `json:"active"`
+	RasaXVersion string `json:"rasa_x_version"`
+}
+
+// ClusterStatusOutput stores status of a kind cluster, it's used by the 'rasactl cluster status' command.
+type ClusterStatusOutput struct {
+	Name              string              `json:"name"`
+	Exists            bool                `json:"exists"`
+	Context           string              `json:"context"`
+	KubernetesVersion string              `json:"kubernetes_version,omitempty"`
+	Nodes             []ClusterNodeOutput `json:"nodes"`
+	IngressController string              `json:"ingress_controller"`
+	CoreDNSConfigured bool                `json:"coredns_configured"`
+}
+
+// ClusterNodeOutput stores status of a kind node.
+type ClusterNodeOutput struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Container string `json:"container"`
+	Ready     bool   `json:"ready"`
+}
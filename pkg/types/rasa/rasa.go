@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -25,9 +25,11 @@ type CredentialsFile struct {
 
 // EndpointsFile defines the endpoints.yaml file used by Rasa OSS.
 type EndpointsFile struct {
-	Models       EndpointModelSpec        `yaml:"models"`
-	TrackerStore EndpointTrackerStoreSpec `yaml:"tracker_store"`
-	EventBroker  EndpointEventBrokerSpec  `yaml:"event_broker"`
+	Models         EndpointModelSpec        `yaml:"models"`
+	TrackerStore   EndpointTrackerStoreSpec `yaml:"tracker_store"`
+	EventBroker    EndpointEventBrokerSpec  `yaml:"event_broker"`
+	LockStore      *EndpointLockStoreSpec   `yaml:"lock_store,omitempty"`
+	ActionEndpoint *EndpointActionSpec      `yaml:"action_endpoint,omitempty"`
 }
 
 // EndpointModelSpec specifies a configuration for a model server.
@@ -58,3 +60,25 @@ type EndpointEventBrokerSpec struct {
 	Password string   `yaml:"password"`
 	Queues   []string `yaml:"queues"`
 }
+
+// EndpointLockStoreSpec specifies a configuration for Lock Store.
+type EndpointLockStoreSpec struct {
+	Type     string `yaml:"type"`
+	URL      string `yaml:"url"`
+	Port     int32  `yaml:"port"`
+	Password string `yaml:"password"`
+	Db       int    `yaml:"db"`
+}
+
+// EndpointActionSpec specifies a configuration for an action server.
+type EndpointActionSpec struct {
+	URL string `yaml:"url"`
+}
+
+// StatusEndpointResponse defines the response of the /status endpoint of Rasa OSS.
+type StatusEndpointResponse struct {
+	ModelFile   string `json:"model_file"`
+	Fingerprint struct {
+		TrainedAt float64 `json:"trained_at"`
+	} `json:"fingerprint"`
+}
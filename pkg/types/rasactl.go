@@ -15,6 +15,8 @@ limitations under the License.
 */
 package types
 
+import "time"
+
 const (
 	RasaCtlLocalDomain     string = "rasactl.localhost"
 	RasaCtlAuthUserEnv     string = "RASACTL_AUTH_USER"
@@ -22,24 +24,110 @@ const (
 )
 
 type RasaCtlFlags struct {
-	Enterprise   RasaCtlEnterpriseFlags
-	StartUpgrade RasaCtlStartUpgradeFlags
-	Start        RasaCtlStartFlags
-	Delete       RasaCtlDeleteFlags
-	Status       RasaCtlStatusFlags
-	ConnectRasa  RasaCtlConnectRasaFlags
-	Global       RasaCtlGlobalFlags
-	Auth         RasaCtlAuthFlags
-	Model        RasaCtlModelFlags
-	Config       RasaCtlConfigFlags
-	Logs         RasaCtlLogsFlags
+	Enterprise     RasaCtlEnterpriseFlags
+	StartUpgrade   RasaCtlStartUpgradeFlags
+	Start          RasaCtlStartFlags
+	Delete         RasaCtlDeleteFlags
+	Status         RasaCtlStatusFlags
+	ConnectRasa    RasaCtlConnectRasaFlags
+	ConnectActions RasaCtlConnectActionsFlags
+	Global         RasaCtlGlobalFlags
+	Auth           RasaCtlAuthFlags
+	Model          RasaCtlModelFlags
+	Config         RasaCtlConfigFlags
+	Logs           RasaCtlLogsFlags
+	Backup         RasaCtlBackupFlags
+	Restore        RasaCtlRestoreFlags
+	History        RasaCtlHistoryFlags
+	Rollback       RasaCtlRollbackFlags
+	Bundle         RasaCtlBundleFlags
+	Apply          RasaCtlApplyFlags
+	Doctor         RasaCtlDoctorFlags
+	SupportBundle  RasaCtlSupportBundleFlags
+	List           RasaCtlListFlags
+	Cluster        RasaCtlClusterFlags
+	PortForward    RasaCtlPortForwardFlags
+	Endpoints      RasaCtlEndpointsFlags
+	Credentials    RasaCtlCredentialsFlags
+	Rasa           RasaCtlRasaFlags
+}
+
+type RasaCtlRasaFlags struct {
+	Enable struct {
+		RasaVersion string
+	}
+}
+
+type RasaCtlEndpointsFlags struct {
+	Output           string
+	RasaXPort        int
+	PostgreSQLPort   int
+	RabbitMQPort     int
+	RedisPort        int
+	ActionServerPort int
+}
+
+type RasaCtlCredentialsFlags struct {
+	Output    string
+	RasaXPort int
+}
+
+type RasaCtlPortForwardFlags struct {
+	RasaXPort        int
+	PostgreSQLPort   int
+	RabbitMQPort     int
+	RedisPort        int
+	ActionServerPort int
+}
+
+type RasaCtlClusterFlags struct {
+	Name        string
+	Image       string
+	WaitTimeout time.Duration
+	Yes         bool
+	Output      string
+}
+
+type RasaCtlListFlags struct {
+	Output string
+}
+
+type RasaCtlHistoryFlags struct {
+	Max int
+}
+
+type RasaCtlRollbackFlags struct {
+	Revision int
+}
+
+type RasaCtlBundleFlags struct {
+	Create struct {
+		File string
+	}
+}
+
+type RasaCtlApplyFlags struct {
+	File string
+}
+
+type RasaCtlDoctorFlags struct {
+	Output string
+}
+
+type RasaCtlSupportBundleFlags struct {
+	File string
 }
 
 type RasaCtlLogsFlags struct {
-	TailLines int64
-	Container string
-	Follow    bool
-	Previous  bool
+	TailLines  int64
+	Container  string
+	Follow     bool
+	Previous   bool
+	All        bool
+	Selector   string
+	Since      time.Duration
+	Grep       string
+	Timestamps bool
 }
 
 type RasaCtlEnterpriseFlags struct {
@@ -47,10 +135,16 @@ type RasaCtlEnterpriseFlags struct {
 		License      string
 		LicenseStdin bool
 	}
+	Status struct {
+		Output string
+	}
 }
 
 type RasaCtlStartUpgradeFlags struct {
 	ValuesFile string
+	Chart      string
+	DryRun     bool
+	Diff       bool
 }
 
 type RasaCtlStartFlags struct {
@@ -60,6 +154,9 @@ type RasaCtlStartFlags struct {
 	RasaXPassword      string
 	RasaXPasswordStdin bool
 	UseEdgeRelease     bool
+	Bundle             string
+	WithRasaServer     bool
+	RasaVersion        string
 }
 
 type RasaCtlDeleteFlags struct {
@@ -70,12 +167,21 @@ type RasaCtlDeleteFlags struct {
 type RasaCtlStatusFlags struct {
 	Details bool
 	Output  string
+	Watch   bool
 }
 
 type RasaCtlConnectRasaFlags struct {
 	RunSeparateWorker bool
 	Port              int
 	ExtraArgs         []string
+	PortForward       bool
+	Image             string
+	Watch             bool
+}
+
+type RasaCtlConnectActionsFlags struct {
+	Port  int
+	Image string
 }
 
 type RasaCtlGlobalFlags struct {
@@ -89,11 +195,15 @@ type RasaCtlAuthFlags struct {
 		Password      string
 		PasswordStdin bool
 	}
+	Status struct {
+		Output string
+	}
 }
 
 type RasaCtlModelFlags struct {
 	Upload struct {
-		File string
+		File    string
+		Timeout time.Duration
 	}
 	Download struct {
 		Name     string
@@ -106,8 +216,23 @@ type RasaCtlModelFlags struct {
 	Delete struct {
 		Name string
 	}
+	List struct {
+		Output string
+	}
 }
 
 type RasaCtlConfigFlags struct {
 	CreateFile bool
 }
+
+type RasaCtlBackupFlags struct {
+	File       string
+	SkipModels bool
+}
+
+type RasaCtlRestoreFlags struct {
+	File         string
+	SkipModels   bool
+	SkipValues   bool
+	ChartVersion string
+}
@@ -24,4 +24,5 @@ const (
 	StateHelmReleaseName   string = "helm-release-name"
 	StateHelmChartVersion  string = "helm-chart-version"
 	StateHelmReleaseStatus string = "helm-release-status"
+	StateEnvironments      string = "environments"
 )
@@ -0,0 +1,71 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+var _ = Describe("Archive", func() {
+
+	var (
+		srcDir string
+		dstDir string
+		file   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		srcDir, err = os.MkdirTemp("", "rasactl-archive-src-")
+		Expect(err).NotTo(HaveOccurred())
+		dstDir, err = os.MkdirTemp("", "rasactl-archive-dst-")
+		Expect(err).NotTo(HaveOccurred())
+		file = filepath.Join(os.TempDir(), "rasactl-archive-test.tar.gz")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(srcDir)
+		os.RemoveAll(dstDir)
+		os.Remove(file)
+	})
+
+	It("extracts files written by CreateArchive", func() {
+		Expect(os.MkdirAll(filepath.Join(srcDir, "models"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "values.yaml"), []byte("rasax: {}\n"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(srcDir, "models", "model.tar.gz"), []byte("model"), 0644)).To(Succeed())
+
+		Expect(utils.CreateArchive(srcDir, file)).To(Succeed())
+		Expect(utils.ExtractArchive(file, dstDir)).To(Succeed())
+
+		values, err := os.ReadFile(filepath.Join(dstDir, "values.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(values)).To(Equal("rasax: {}\n"))
+
+		model, err := os.ReadFile(filepath.Join(dstDir, "models", "model.tar.gz"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(model)).To(Equal("model"))
+	})
+
+	It("returns an error if the archive doesn't exist", func() {
+		Expect(utils.ExtractArchive(file, dstDir)).NotTo(Succeed())
+	})
+})
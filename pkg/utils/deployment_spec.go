@@ -0,0 +1,96 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+)
+
+// ReadDeploymentSpec reads a deployment specification from a given file.
+// Relative paths used in the specification are resolved against the directory of the file.
+func ReadDeploymentSpec(file string) (*types.DeploymentSpec, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &types.DeploymentSpec{}
+	if err := yaml.UnmarshalStrict(content, spec); err != nil {
+		return nil, xerrors.Errorf("can't parse the %s deployment spec: %w", file, err)
+	}
+
+	if spec.Name == "" {
+		return nil, xerrors.Errorf("the %s deployment spec doesn't define a deployment name", file)
+	}
+
+	if err := ValidateName(spec.Name); err != nil {
+		return nil, err
+	}
+
+	if spec.Enterprise != nil && spec.Enterprise.LicenseFile == "" && spec.Enterprise.LicenseEnv == "" {
+		return nil, xerrors.Errorf("the %s deployment spec has to define licenseFile or licenseEnv for the Enterprise license", file)
+	}
+
+	dir := filepath.Dir(file)
+	spec.ValuesFile = resolvePath(dir, spec.ValuesFile)
+	spec.ProjectPath = resolvePath(dir, spec.ProjectPath)
+	if spec.Enterprise != nil {
+		spec.Enterprise.LicenseFile = resolvePath(dir, spec.Enterprise.LicenseFile)
+	}
+	for i := range spec.Models {
+		spec.Models[i].File = resolvePath(dir, spec.Models[i].File)
+	}
+
+	return spec, nil
+}
+
+// ReadDeploymentSpecLicense returns an Enterprise license referenced by a deployment specification.
+func ReadDeploymentSpecLicense(spec *types.DeploymentEnterpriseSpec) (string, error) {
+	if spec.LicenseEnv != "" {
+		license, ok := os.LookupEnv(spec.LicenseEnv)
+		if !ok {
+			return "", xerrors.Errorf("the %s environment variable with the Enterprise license is not set", spec.LicenseEnv)
+		}
+		return strings.TrimSpace(license), nil
+	}
+
+	license, err := ioutil.ReadFile(spec.LicenseFile)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(license)), nil
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	absPath, err := filepath.Abs(filepath.Join(dir, path))
+	if err != nil {
+		return filepath.Join(dir, path)
+	}
+	return absPath
+}
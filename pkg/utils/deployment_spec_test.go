@@ -0,0 +1,104 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/types"
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+var _ = Describe("Deployment spec", func() {
+
+	var (
+		dir  string
+		file string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "rasactl-deployment-spec-")
+		Expect(err).NotTo(HaveOccurred())
+		file = filepath.Join(dir, "deployment.yaml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("reads a deployment spec and resolves relative paths", func() {
+		spec := `
+name: my-deployment
+chart:
+  version: 4.3.3
+valuesFile: values.yaml
+values:
+  rasax:
+    tag: 1.0.0
+enterprise:
+  licenseFile: license.txt
+environments:
+  - name: production
+    url: http://rasa-production:5005
+    token: token
+models:
+  - file: models/model.tar.gz
+    tags: [production]
+`
+		Expect(os.WriteFile(file, []byte(spec), 0644)).To(Succeed())
+
+		r, err := utils.ReadDeploymentSpec(file)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Name).To(Equal("my-deployment"))
+		// The default release name is used only if a release name is not defined by the spec.
+		Expect(r.Chart.ReleaseName).To(BeEmpty())
+		Expect(r.Chart.Version).To(Equal("4.3.3"))
+		Expect(r.ValuesFile).To(Equal(filepath.Join(dir, "values.yaml")))
+		Expect(r.Values).To(HaveKey("rasax"))
+		Expect(r.Enterprise.LicenseFile).To(Equal(filepath.Join(dir, "license.txt")))
+		Expect(r.Environments).To(HaveLen(1))
+		Expect(r.Models[0].File).To(Equal(filepath.Join(dir, "models", "model.tar.gz")))
+		Expect(r.Models[0].Tags).To(Equal([]string{"production"}))
+	})
+
+	It("returns an error if the spec doesn't define a name", func() {
+		Expect(os.WriteFile(file, []byte("valuesFile: values.yaml\n"), 0644)).To(Succeed())
+
+		_, err := utils.ReadDeploymentSpec(file)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error for unknown fields", func() {
+		Expect(os.WriteFile(file, []byte("name: my-deployment\nvalue: {}\n"), 0644)).To(Succeed())
+
+		_, err := utils.ReadDeploymentSpec(file)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reads an Enterprise license from an environment variable", func() {
+		os.Setenv("RASACTL_TEST_LICENSE", "license\n")
+		defer os.Unsetenv("RASACTL_TEST_LICENSE")
+
+		license, err := utils.ReadDeploymentSpecLicense(&types.DeploymentEnterpriseSpec{LicenseEnv: "RASACTL_TEST_LICENSE"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(license).To(Equal("license"))
+	})
+})
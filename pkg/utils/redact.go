@@ -0,0 +1,57 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"regexp"
+)
+
+// RedactedValue is used instead of sensitive values.
+const RedactedValue string = "REDACTED"
+
+// sensitiveKeyRegex matches keys that store sensitive values, such as passwords, tokens, or cookies.
+// Keys are matched by the "key" word only as a suffix, e.g. accessKey or private_key,
+// a plain "key" field is used by non-sensitive values, e.g. tolerations.
+var sensitiveKeyRegex = regexp.MustCompile(`(?i:password|passwd|token|secret|salt|license|credentials|apikey|api_key|cookie)|[a-z]Key$|(?i:_key)$`)
+
+// RedactValues returns a copy of given values where values of keys that look sensitive are replaced with a placeholder.
+func RedactValues(values map[string]interface{}) map[string]interface{} {
+	redacted, _ := redact(values).(map[string]interface{})
+	return redacted
+}
+
+func redact(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for key, v := range value {
+			if _, isMap := v.(map[string]interface{}); !isMap && v != nil && v != "" && sensitiveKeyRegex.MatchString(key) {
+				result[key] = RedactedValue
+				continue
+			}
+			result[key] = redact(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = redact(v)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2021 Rasa Technologies GmbH
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/RasaHQ/rasactl/pkg/utils"
+)
+
+var _ = Describe("Redact", func() {
+
+	It("redacts sensitive values", func() {
+		values := map[string]interface{}{
+			"rasax": map[string]interface{}{
+				"passwordSalt": "salt",
+				"token":        "token",
+				"initialUser": map[string]interface{}{
+					"username": "me",
+					"password": "password",
+				},
+			},
+			"global": map[string]interface{}{
+				"postgresql": map[string]interface{}{
+					"postgresqlPassword": "password",
+					"existingSecret":     "",
+				},
+			},
+			"environments": []interface{}{
+				map[string]interface{}{"name": "production", "token": "token"},
+			},
+		}
+
+		redacted := utils.RedactValues(values)
+		Expect(redacted).To(Equal(map[string]interface{}{
+			"rasax": map[string]interface{}{
+				"passwordSalt": utils.RedactedValue,
+				"token":        utils.RedactedValue,
+				"initialUser": map[string]interface{}{
+					"username": "me",
+					"password": utils.RedactedValue,
+				},
+			},
+			"global": map[string]interface{}{
+				"postgresql": map[string]interface{}{
+					"postgresqlPassword": utils.RedactedValue,
+					"existingSecret":     "",
+				},
+			},
+			"environments": []interface{}{
+				map[string]interface{}{"name": "production", "token": utils.RedactedValue},
+			},
+		}))
+
+		// The original values are not modified.
+		Expect(values["rasax"].(map[string]interface{})["token"]).To(Equal("token"))
+	})
+
+	It("redacts cookies and keys", func() {
+		values := map[string]interface{}{
+			"rabbitmq": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"erlangCookie": "cookie",
+				},
+			},
+			"storage": map[string]interface{}{
+				"accessKey":   "access",
+				"private_key": "private",
+			},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "rasactl", "value": "true"},
+			},
+		}
+
+		redacted := utils.RedactValues(values)
+		Expect(redacted).To(Equal(map[string]interface{}{
+			"rabbitmq": map[string]interface{}{
+				"auth": map[string]interface{}{
+					"erlangCookie": utils.RedactedValue,
+				},
+			},
+			"storage": map[string]interface{}{
+				"accessKey":   utils.RedactedValue,
+				"private_key": utils.RedactedValue,
+			},
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "rasactl", "value": "true"},
+			},
+		}))
+	})
+})
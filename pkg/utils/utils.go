@@ -17,7 +17,6 @@ package utils
 
 import (
 	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -311,27 +310,6 @@ func RasaXVersionConstrains(version string, constraint string) bool {
 	return c.Check(v)
 }
 
-// StringSliceToJSON converts [][]string{} to JSON.
-func StringSliceToJSON(d [][]string) (string, error) {
-
-	data := map[string]interface{}{}
-
-	for _, item := range d {
-		field := strings.ToLower(item[0])
-		field = strings.ReplaceAll(field, " ", "_")
-		field = strings.ReplaceAll(field, ":", "")
-
-		data[field] = item[1]
-	}
-
-	jsonByte, err := json.Marshal(data)
-	if err != nil {
-		return "", err
-	}
-
-	return string(jsonByte), nil
-}
-
 // CheckHelmChartDir checks if a local Helm chart directory exists.
 func CheckHelmChartDir() {
 	name := strings.TrimSpace(types.HelmChartNameRasaX)
@@ -51,12 +51,6 @@ var _ = Describe("Utils", func() {
 		Expect(err).To(Not(BeNil()))
 	})
 
-	It("convert string slice to JSON", func() {
-		d := [][]string{{"test:", "test"}}
-		str, _ := utils.StringSliceToJSON(d)
-		Expect(str).To(Equal("{\"test\":\"test\"}"))
-	})
-
 	Describe("read Rasa X URL from environment variables", func() {
 		viper.AutomaticEnv() // read in environment variables that match
 		viper.SetEnvPrefix("rasactl")